@@ -9,16 +9,50 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/pkg/xslices"
 	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	otelTrace "go.opentelemetry.io/otel/trace"
 )
 
-const traceIDLogField = "otel-trace-id"
+const (
+	traceIDLogField = "otel-trace-id"
+	spanIDLogField  = "otel-span-id"
+)
 
 var _ spans.Adapter = (*adapter)(nil)
 
 type adapter struct {
-	tracer   otelTrace.Tracer
-	detailer trace.Detailer
+	tracer     otelTrace.Tracer
+	detailer   trace.Detailer
+	converters attributeConverters
+
+	// eventsLimit is a maximum number of Log events per span, zero means unlimited.
+	eventsLimit int
+
+	// contextErrorStatus is a span status for context cancellation and deadline errors.
+	contextErrorStatus codes.Code
+
+	// okStatus enables codes.Ok status for spans ended without errors.
+	okStatus bool
+
+	// retryDecisions enables ydb.idempotent and ydb.retry.decision span attributes.
+	retryDecisions bool
+
+	// leaks is an optional detector of unended spans.
+	leaks *leakDetector
+	// plans is an optional capture of plans of slow queries.
+	plans *planCapture
+	// identity is an optional identity of driver attached to spans.
+	identity *driverIdentity
+
+	// correlationOnly disables spans creation, adapter only passes trace context into ydb log fields.
+	correlationOnly bool
+
+	// querySpanNames enables naming of query spans by statement type and table, like "UPSERT series".
+	querySpanNames bool
+
+	// sanitizeQueryText enables replacing of literals and comments in query text attributes with '?'.
+	sanitizeQueryText bool
 }
 
 func (cfg *adapter) Details() trace.Details {
@@ -26,37 +60,146 @@ func (cfg *adapter) Details() trace.Details {
 }
 
 func (cfg *adapter) SpanFromContext(ctx context.Context) spans.Span {
+	if cfg.correlationOnly || !enabledInContext(ctx, trace.DetailsAll) {
+		return &span{
+			span:    nonRecordingSpan(ctx),
+			adapter: cfg,
+		}
+	}
+
+	s := otelTrace.SpanFromContext(ctx)
+
 	return &span{
-		span: otelTrace.SpanFromContext(ctx),
+		span:    s,
+		adapter: cfg,
+		state:   spanStateFromContext(ctx, s),
 	}
 }
 
 func (cfg *adapter) Start(ctx context.Context, operationName string, fields ...spans.KeyValue) (
 	context.Context, spans.Span,
 ) {
+	return cfg.start(ctx, operationName, fields)
+}
+
+func (cfg *adapter) start(
+	ctx context.Context, operationName string, fields []spans.KeyValue, opts ...otelTrace.SpanStartOption,
+) (context.Context, *span) {
+	if cfg.correlationOnly {
+		return cfg.startCorrelationOnly(ctx)
+	}
+
+	if !enabledInContext(ctx, operationDetails(operationName)) {
+		return ctx, &span{
+			span:    nonRecordingSpan(ctx),
+			adapter: cfg,
+		}
+	}
+
+	ctxAttrs := attributesFromContext(ctx)
+
+	query, hasQuery := queryFromFields(fields)
+	if hasQuery && cfg.sanitizeQueryText {
+		fields = sanitizeQueryFields(fields)
+	}
+
+	attrs := cfg.converters.fieldsToAttributes(fields)
+	attrs = append(attrs, cfg.identity.attributes()...)
+	attrs = append(attrs, deadlineAttributes(ctx)...)
+	attrs = append(attrs, ctxAttrs...)
+	if hasQuery {
+		summary := analyzeYQL(query)
+		attrs = append(attrs, summary.attributes()...)
+		if name := summary.spanName(); cfg.querySpanNames && name != "" {
+			attrs = append(attrs, attribute.String(ydbOperationAttribute, operationName))
+			operationName = name
+		}
+	}
+
 	childCtx, s := cfg.tracer.Start(ctx, operationName,
-		otelTrace.WithAttributes(fieldsToAttributes(fields)...),
+		append(opts, otelTrace.WithAttributes(attrs...))...,
 	)
 
 	if spanCtx := s.SpanContext(); spanCtx.IsValid() {
-		logFields := log.FieldsFromContext(childCtx)
-		if !slices.Contains(xslices.Transform(logFields, func(field log.Field) string {
-			return field.Key()
-		}), traceIDLogField) {
-			childCtx = log.WithFields(childCtx, log.String(traceIDLogField, spanCtx.TraceID().String()))
+		childCtx = withLogFields(childCtx, log.String(traceIDLogField, spanCtx.TraceID().String()))
+	}
+
+	state := &spanState{
+		owner: s,
+		attrs: ctxAttrs,
+	}
+	if cfg.eventsLimit > 0 {
+		state.budget = newEventsBudget(cfg.eventsLimit)
+	}
+	if parent := parentSpanState(ctx); parent != nil {
+		state.idempotent = parent.idempotent
+	}
+	if idempotent, ok := idempotentFromFields(fields); ok {
+		state.idempotent = idempotent
+		state.retryLoop = true
+		if cfg.retryDecisions {
+			s.SetAttributes(attribute.Bool(idempotentAttribute, idempotent))
 		}
 	}
 
-	return childCtx, &span{
-		span: s,
+	if cfg.leaks != nil {
+		cfg.leaks.track(cfg, state, operationName)
+	}
+
+	return contextWithSpanState(childCtx, state), &span{
+		span:    s,
+		adapter: cfg,
+		state:   state,
+		started: true,
+	}
+}
+
+// startCorrelationOnly passes trace and span IDs of parent span into ydb log fields without starting a span.
+func (cfg *adapter) startCorrelationOnly(ctx context.Context) (context.Context, *span) {
+	if spanCtx := otelTrace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		ctx = withLogFields(ctx,
+			log.String(traceIDLogField, spanCtx.TraceID().String()),
+			log.String(spanIDLogField, spanCtx.SpanID().String()),
+		)
+	}
+
+	return ctx, &span{
+		span:    nonRecordingSpan(ctx),
+		adapter: cfg,
 	}
 }
 
+// nonRecordingSpan returns span which carries span context of ctx and ignores all calls.
+func nonRecordingSpan(ctx context.Context) otelTrace.Span {
+	return otelTrace.SpanFromContext(otelTrace.ContextWithSpanContext(ctx, otelTrace.SpanContextFromContext(ctx)))
+}
+
+// withLogFields adds fields into ydb log fields of ctx skipping keys which are already present.
+func withLogFields(ctx context.Context, fields ...log.Field) context.Context {
+	keys := xslices.Transform(log.FieldsFromContext(ctx), func(field log.Field) string {
+		return field.Key()
+	})
+
+	fields = slices.DeleteFunc(fields, func(field log.Field) bool {
+		return slices.Contains(keys, field.Key())
+	})
+	if len(fields) == 0 {
+		return ctx
+	}
+
+	return log.WithFields(ctx, fields...)
+}
+
 // SpansAdapter returns spans.Adapter by tracer and opts
 func SpansAdapter(tracer otelTrace.Tracer, opts ...tracesOption) spans.Adapter {
+	return newAdapter(tracer, opts...)
+}
+
+func newAdapter(tracer otelTrace.Tracer, opts ...tracesOption) *adapter {
 	adapter := &adapter{
-		tracer:   tracerFrom(tracer),
-		detailer: trace.DetailsAll,
+		tracer:             tracerFrom(tracer),
+		detailer:           trace.DetailsAll,
+		contextErrorStatus: codes.Unset,
 	}
 	for _, opt := range opts {
 		opt.applyTracesOption(adapter)
@@ -68,5 +211,40 @@ func SpansAdapter(tracer otelTrace.Tracer, opts ...tracesOption) spans.Adapter {
 // WithTracer enables ydb-go-sdk spans export via OpenTelemetry.
 // If tracer is nil, otel.Tracer("ydb-go-sdk") is used.
 func WithTracer(tracer otelTrace.Tracer, opts ...tracesOption) ydb.Option {
-	return spans.WithTraces(SpansAdapter(tracer, opts...))
+	a := newAdapter(tracer, opts...)
+
+	return withAdapterTraces(spans.WithTraces(a), a)
+}
+
+// withAdapterTraces adds driver close hooks of leak detectors, query hooks of plan capture
+// and driver init hooks of identities of adapters into option.
+func withAdapterTraces(opt ydb.Option, adapters ...*adapter) ydb.Option {
+	opts := []ydb.Option{opt}
+	var (
+		detectors []*leakDetector
+		owners    = make(map[*leakDetector][]*adapter)
+	)
+	for _, a := range adapters {
+		if a.leaks != nil {
+			if _, ok := owners[a.leaks]; !ok {
+				detectors = append(detectors, a.leaks)
+			}
+			owners[a.leaks] = append(owners[a.leaks], a)
+		}
+		if a.plans != nil {
+			opts = append(opts, ydb.WithTraceQuery(a.plans.queryTrace()))
+		}
+		if a.identity != nil {
+			opts = append(opts, ydb.WithTraceDriver(a.identity.driverTrace()))
+		}
+	}
+	for _, d := range detectors {
+		opts = append(opts, ydb.WithTraceDriver(d.driverTrace(owners[d]...)))
+	}
+
+	if len(opts) == 1 {
+		return opt
+	}
+
+	return ydb.MergeOptions(opts...)
 }
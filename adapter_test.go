@@ -0,0 +1,111 @@
+package ydb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestSpansAdapter(opts ...tracesOption) (*adapter, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder))
+
+	a, _ := SpansAdapter(provider.Tracer("test"), opts...).(*adapter)
+
+	return a, recorder
+}
+
+func spanAttributes(s sdkTrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	return attributesMap(s.Attributes())
+}
+
+func TestAdapterEventsLimit(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithEventsLimit(2))
+
+	ctx, s := a.Start(context.Background(), "stream")
+	for range 3 {
+		s.Log("recv")
+	}
+	a.SpanFromContext(ctx).Log("send")
+	a.SpanFromContext(ctx).Log("send")
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Len(t, ended[0].Events(), 2)
+
+	attrs := spanAttributes(ended[0])
+	require.Equal(t, int64(3), attrs[eventsDroppedAttribute].AsInt64())
+	require.Equal(t, []string{"recv", "send"}, attrs[eventsDroppedMessagesAttribute].AsStringSlice())
+	require.Equal(t, []int64{1, 2}, attrs[eventsDroppedCountsAttribute].AsInt64Slice())
+}
+
+func TestAdapterEventsDroppedMessagesLimit(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithEventsLimit(1))
+
+	_, s := a.Start(context.Background(), "stream")
+	s.Log("first")
+	for i := range maxDroppedMessages + 5 {
+		s.Log(fmt.Sprintf("query %d", i))
+	}
+	s.Log("query 0")
+	s.End()
+
+	attrs := spanAttributes(recorder.Ended()[0])
+	require.Equal(t, int64(maxDroppedMessages+6), attrs[eventsDroppedAttribute].AsInt64())
+
+	messages := attrs[eventsDroppedMessagesAttribute].AsStringSlice()
+	counts := attrs[eventsDroppedCountsAttribute].AsInt64Slice()
+	require.Len(t, messages, maxDroppedMessages+1)
+	require.Equal(t, droppedOtherMessage, messages[maxDroppedMessages])
+	require.Equal(t, int64(5), counts[maxDroppedMessages])
+	require.Equal(t, "query 0", messages[0])
+	require.Equal(t, int64(2), counts[0])
+}
+
+func TestAdapterEventsUnlimitedByDefault(t *testing.T) {
+	a, recorder := newTestSpansAdapter()
+
+	_, s := a.Start(context.Background(), "stream")
+	for range 10 {
+		s.Log("recv")
+	}
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Len(t, ended[0].Events(), 10)
+	require.NotContains(t, spanAttributes(ended[0]), attribute.Key(eventsDroppedAttribute))
+}
+
+func TestAdapterCorrelationOnly(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder))
+	a, _ := SpansAdapter(provider.Tracer("test"), WithCorrelationOnly()).(*adapter)
+
+	ctx, parent := provider.Tracer("app").Start(context.Background(), "request")
+
+	childCtx, s := a.Start(ctx, "ydb.query.Client.Exec")
+	s.Log("event")
+	s.End()
+	a.SpanFromContext(childCtx).Log("event")
+
+	require.Empty(t, recorder.Ended())
+	require.Empty(t, recorder.Started()[0].Events())
+
+	fields := map[string]string{}
+	for _, field := range log.FieldsFromContext(childCtx) {
+		fields[field.Key()] = field.StringValue()
+	}
+	require.Equal(t, parent.SpanContext().TraceID().String(), fields[traceIDLogField])
+	require.Equal(t, parent.SpanContext().SpanID().String(), fields[spanIDLogField])
+
+	parent.End()
+	require.Len(t, recorder.Ended(), 1)
+}
@@ -0,0 +1,121 @@
+package ydb
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// maxCachedStarters is a limit of span starters cached by StartSpan and StartQuerySpan.
+	maxCachedStarters = 64
+	// maxCachedStarterOptions is a limit of options of cached span starters.
+	maxCachedStarterOptions = 8
+)
+
+// SpanStarter starts client spans of application operations over YDB with the same attributes,
+// query text sanitization, per-call attributes and error classification as ydb-go-sdk spans.
+type SpanStarter struct {
+	adapter *adapter
+}
+
+// NewSpanStarter returns SpanStarter which is configured once by options like SpansAdapter.
+// If tracer is nil, otel.Tracer("ydb-go-sdk") is used.
+func NewSpanStarter(tracer otelTrace.Tracer, opts ...tracesOption) *SpanStarter {
+	return &SpanStarter{adapter: newAdapter(tracer, opts...)}
+}
+
+// Start starts client span of application operation. Span fields are converted into attributes.
+func (s *SpanStarter) Start(ctx context.Context, name string, fields ...spans.KeyValue) (
+	context.Context, spans.Span,
+) {
+	return s.adapter.start(ctx, name, fields, otelTrace.WithSpanKind(otelTrace.SpanKindClient))
+}
+
+// StartQuery starts client span of application operation which executes query.
+// Span gets query attribute and db.operation.name and db.collection.name attributes
+// derived from query text like spans of ydb-go-sdk query operations.
+func (s *SpanStarter) StartQuery(ctx context.Context, name, query string, fields ...spans.KeyValue) (
+	context.Context, spans.Span,
+) {
+	return s.Start(ctx, name, append([]spans.KeyValue{log.String(queryFieldKeys[0], query)}, fields...)...)
+}
+
+// StartSpan starts client span of application operation over YDB with span starter of tracer and
+// options. Starters are cached for comparable tracers and options, so calls do not rebuild them.
+// If tracer is nil, otel.Tracer("ydb-go-sdk") is used.
+func StartSpan(ctx context.Context, tracer otelTrace.Tracer, name string, fields []spans.KeyValue,
+	opts ...tracesOption,
+) (context.Context, spans.Span) {
+	return cachedSpanStarter(tracer, opts).Start(ctx, name, fields...)
+}
+
+// StartQuerySpan starts client span of application operation which executes query like
+// SpanStarter.StartQuery with span starter of tracer and options cached like by StartSpan.
+func StartQuerySpan(ctx context.Context, tracer otelTrace.Tracer, name, query string,
+	opts ...tracesOption,
+) (context.Context, spans.Span) {
+	return cachedSpanStarter(tracer, opts).StartQuery(ctx, name, query)
+}
+
+// spanStarterKey identifies cached span starter by tracer and options.
+type spanStarterKey struct {
+	tracer otelTrace.Tracer
+	opts   [maxCachedStarterOptions]tracesOption
+}
+
+var spanStarters struct {
+	mu       sync.Mutex
+	starters map[spanStarterKey]*SpanStarter
+}
+
+// cachedSpanStarter returns cached span starter of tracer and options. Starters of non-comparable
+// tracers or options (like WithAttributeConverter) and starters over cache limit are not cached.
+func cachedSpanStarter(tracer otelTrace.Tracer, opts []tracesOption) *SpanStarter {
+	key, ok := newSpanStarterKey(tracer, opts)
+	if !ok {
+		return NewSpanStarter(tracer, opts...)
+	}
+
+	spanStarters.mu.Lock()
+	defer spanStarters.mu.Unlock()
+
+	if starter, ok := spanStarters.starters[key]; ok {
+		return starter
+	}
+
+	starter := NewSpanStarter(tracer, opts...)
+	if spanStarters.starters == nil {
+		spanStarters.starters = make(map[spanStarterKey]*SpanStarter)
+	}
+	if len(spanStarters.starters) < maxCachedStarters {
+		spanStarters.starters[key] = starter
+	}
+
+	return starter
+}
+
+func newSpanStarterKey(tracer otelTrace.Tracer, opts []tracesOption) (key spanStarterKey, ok bool) {
+	if len(opts) > maxCachedStarterOptions || !isComparable(tracer) {
+		return key, false
+	}
+
+	key.tracer = tracer
+	for i, opt := range opts {
+		if !isComparable(opt) {
+			return key, false
+		}
+		key.opts[i] = opt
+	}
+
+	return key, true
+}
+
+// isComparable reports whether v can be used as a part of map key without panic.
+func isComparable(v any) bool {
+	return v == nil || reflect.ValueOf(v).Comparable()
+}
@@ -0,0 +1,94 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanStarterStartQuery(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)).Tracer("app")
+	starter := NewSpanStarter(tracer, WithSanitizedQueryText())
+
+	ctx := ContextWithAttributes(context.Background(), attribute.String("order.id", "42"))
+	_, s := starter.StartQuery(ctx, "selectTx",
+		"SELECT series_id FROM `/local/series` WHERE title = 'secret' LIMIT 10",
+		log.Int("limit", 10),
+	)
+	s.Error(context.Canceled)
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "selectTx", ended[0].Name())
+	require.Equal(t, otelTrace.SpanKindClient, ended[0].SpanKind())
+	require.Equal(t, codes.Unset, ended[0].Status().Code)
+
+	attrs := spanAttributes(ended[0])
+	require.Equal(t, "SELECT series_id FROM `/local/series` WHERE title = ? LIMIT ?", attrs["query"].AsString())
+	require.Equal(t, "SELECT", attrs[dbOperationNameAttribute].AsString())
+	require.Equal(t, "/local/series", attrs[dbCollectionNameAttribute].AsString())
+	require.Equal(t, int64(10), attrs["limit"].AsInt64())
+	require.Equal(t, "42", attrs["order.id"].AsString())
+	require.True(t, attrs[cancelledAttribute].AsBool())
+}
+
+func TestSpanStarterOptions(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)).Tracer("app")
+	starter := NewSpanStarter(tracer, WithQuerySpanNames(), WithOKStatus())
+
+	for range 2 {
+		_, s := starter.StartQuery(context.Background(), "upsertData", "UPSERT INTO series SELECT 1")
+		s.End()
+	}
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	for _, span := range ended {
+		require.Equal(t, "UPSERT series", span.Name())
+		require.Equal(t, codes.Ok, span.Status().Code)
+		require.Equal(t, "upsertData", spanAttributes(span)[ydbOperationAttribute].AsString())
+		require.Equal(t, "UPSERT INTO series SELECT 1", spanAttributes(span)["query"].AsString())
+	}
+}
+
+func TestStartQuerySpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)).Tracer("app")
+
+	for range 2 {
+		_, s := StartQuerySpan(context.Background(), tracer, "selectTx", "SELECT * FROM series WHERE id = 1",
+			WithQuerySpanNames(),
+		)
+		s.End()
+	}
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	for _, span := range ended {
+		require.Equal(t, "SELECT series", span.Name())
+		require.Equal(t, otelTrace.SpanKindClient, span.SpanKind())
+		require.Equal(t, "SELECT * FROM series WHERE id = 1", spanAttributes(span)["query"].AsString())
+	}
+
+	require.Same(t,
+		cachedSpanStarter(tracer, []tracesOption{WithQuerySpanNames()}),
+		cachedSpanStarter(tracer, []tracesOption{WithQuerySpanNames()}),
+	)
+
+	converter := WithAttributeConverter(func(string, time.Month) []attribute.KeyValue { return nil })
+	require.NotSame(t,
+		cachedSpanStarter(tracer, []tracesOption{converter}),
+		cachedSpanStarter(tracer, []tracesOption{converter}),
+	)
+}
@@ -0,0 +1,107 @@
+package ydb
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	overflowAttribute      = "otel.metric.overflow"
+	overflowMetricName     = "ydb.metrics.overflow"
+	overflowMetricLabel    = "metric"
+	overflowLabelsCacheKey = "\xffoverflow"
+
+	// maxFoldedLabelSets is a limit of tracked distinct folded label sets per instrument,
+	// label sets over it are folded without counting.
+	maxFoldedLabelSets = 1024
+)
+
+// cardinalityLimit is a limit of label sets per instrument shared by metrics configs.
+type cardinalityLimit struct {
+	limit  int
+	folded metric.Int64Counter
+}
+
+func newCardinalityLimit(meter metric.Meter, limit int, handleError func(error)) *cardinalityLimit {
+	folded, err := meter.Int64Counter(overflowMetricName,
+		metric.WithDescription("Number of distinct ydb-go-sdk metric label sets folded into overflow series"),
+		metric.WithUnit("{label_set}"),
+	)
+	if err != nil {
+		handleError(err)
+		folded = noop.Int64Counter{}
+	}
+
+	return &cardinalityLimit{
+		limit:  limit,
+		folded: folded,
+	}
+}
+
+// limiter returns limiter of label sets of instrument, nil if limit is not set.
+func (l *cardinalityLimit) limiter(instrumentName string) *cardinalityLimiter {
+	if l == nil {
+		return nil
+	}
+
+	return &cardinalityLimiter{
+		limit:   l.limit,
+		counter: l.folded,
+		attrs:   metric.WithAttributes(attribute.String(overflowMetricLabel, instrumentName)),
+		seen:    make(map[string]struct{}),
+		folded:  make(map[string]struct{}),
+	}
+}
+
+// cardinalityLimiter tracks distinct label sets of instrument and folds label sets over limit.
+type cardinalityLimiter struct {
+	limit   int
+	counter metric.Int64Counter
+	attrs   metric.MeasurementOption
+
+	mu     sync.Mutex
+	seen   map[string]struct{}
+	folded map[string]struct{}
+}
+
+// allow reports whether label set with key fits into limit. Otherwise label set must be folded
+// into overflow set, first fold of each label set is counted.
+func (l *cardinalityLimiter) allow(key string) bool {
+	l.mu.Lock()
+	_, ok := l.seen[key]
+	if !ok && len(l.seen) < l.limit {
+		l.seen[key] = struct{}{}
+		ok = true
+	}
+
+	count := false
+	if !ok {
+		if _, folded := l.folded[key]; !folded && len(l.folded) < maxFoldedLabelSets {
+			l.folded[key] = struct{}{}
+			count = true
+		}
+	}
+	l.mu.Unlock()
+
+	if count {
+		l.counter.Add(context.Background(), 1, l.attrs)
+	}
+
+	return ok
+}
+
+// overflowAttributes returns attribute set which replaces label sets over limit with constant
+// attributes of instrument and driver identity snapshot of series key.
+func overflowAttributes(constAttrs []attribute.KeyValue, identity *[]attribute.KeyValue) []attribute.KeyValue {
+	attrs := append(slices.Clip(constAttrs), attribute.Bool(overflowAttribute, true))
+	if identity != nil {
+		attrs = append(attrs, *identity...)
+	}
+
+	return attrs
+}
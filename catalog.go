@@ -0,0 +1,112 @@
+package ydb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MetricDescription is a description and UCUM unit of ydb-go-sdk metric.
+type MetricDescription struct {
+	Description string
+	Unit        string
+}
+
+// metricsCatalog describes known ydb-go-sdk metrics by dotted path of subsystems and metric name,
+// like "ydb.query.session.count". Path does not depend on namespace and separator options.
+var metricsCatalog = map[string]MetricDescription{
+	"ydb.info": {"Information about ydb-go-sdk version, value is always 1", "1"},
+
+	"ydb.driver.balancer.endpoints":            {"Number of endpoints discovered by balancer", "{endpoint}"},
+	"ydb.driver.balancer.discoveries":          {"Number of endpoints discoveries by status and cause", "{discovery}"},
+	"ydb.driver.balancer.updates":              {"Number of balancer updates by cause", "{update}"},
+	"ydb.driver.conns":                         {"Number of connections to YDB nodes", "{connection}"},
+	"ydb.driver.conn.banned":                   {"Number of banned connections to YDB nodes", "{connection}"},
+	"ydb.driver.conn.request_statuses":         {"Number of requests to YDB nodes by status", "{request}"},
+	"ydb.driver.conn.request_methods":          {"Number of requests to YDB nodes by method", "{request}"},
+	"ydb.driver.transaction_locks_invalidated": {"Number of transactions with invalidated locks", "{transaction}"},
+
+	"ydb.query.pool.size.limit":              {"Limit of query sessions pool size", "{session}"},
+	"ydb.query.pool.size.idle":               {"Number of idle sessions in query sessions pool", "{session}"},
+	"ydb.query.pool.size.index":              {"Number of sessions in query sessions pool", "{session}"},
+	"ydb.query.pool.size.waiters_queue":      {"Number of waiters for session of query sessions pool", "{waiter}"},
+	"ydb.query.pool.size.in_use":             {"Number of sessions of query sessions pool in use", "{session}"},
+	"ydb.query.pool.size.create_in_progress": {"Number of sessions of query sessions pool being created", "{session}"},
+	"ydb.query.pool.get.latency":             {"Time of waiting for session of query sessions pool", "s"},
+	"ydb.query.session.count":                {"Number of query sessions", "{session}"},
+	"ydb.query.result.set.rows":              {"Number of rows in query result sets", "{row}"},
+	"ydb.query.operation.latency":            {"Latency of ydb queries by operation and collection", "s"},
+
+	"ydb.table.sessions":              {"Number of table sessions by node", "{session}"},
+	"ydb.table.pool.limit":            {"Limit of table sessions pool size", "{session}"},
+	"ydb.table.pool.index":            {"Number of sessions in table sessions pool", "{session}"},
+	"ydb.table.pool.idle":             {"Number of idle sessions in table sessions pool", "{session}"},
+	"ydb.table.pool.wait":             {"Number of waiters for session of table sessions pool", "{waiter}"},
+	"ydb.table.pool.createInProgress": {"Number of sessions of table sessions pool being created", "{session}"},
+	"ydb.table.pool.get":              {"Number of sessions taken from table sessions pool", "{session}"},
+	"ydb.table.pool.put":              {"Number of sessions returned into table sessions pool", "{session}"},
+	"ydb.table.pool.with":             {"Number of operations in progress with table sessions pool", "{operation}"},
+	"ydb.table.pool.in_use":           {"Number of sessions of table sessions pool in use", "{session}"},
+	"ydb.table.pool.get.latency":      {"Time of waiting for session of table sessions pool", "s"},
+
+	"ydb.retry.errors": {"Number of errors of retry operations by status, label and finality", "{error}"},
+
+	"ydb.database.sql.conns": {"Number of database/sql connections", "{connection}"},
+	"ydb.database.sql.query": {"Number of database/sql queries by status and query mode", "{query}"},
+	"ydb.database.sql.exec":  {"Number of database/sql executions by status and query mode", "{query}"},
+	"ydb.database.sql.tx":    {"Number of database/sql transactions in progress", "{transaction}"},
+}
+
+// metricsCatalogByName describes ydb-go-sdk metrics which are reported by many subsystems,
+// description is formatted with path of subsystem like "query.do.tx".
+var metricsCatalogByName = map[string]MetricDescription{
+	"errs":     {"Number of errors of ydb %s operations by status", "{error}"},
+	"latency":  {"Latency of ydb %s operations", "s"},
+	"attempts": {"Number of attempts of ydb %s operations", "{attempt}"},
+}
+
+// metricDescriptions are user descriptions of metrics which extend or override catalog.
+type metricDescriptions map[string]MetricDescription
+
+// override returns user description of metric by dotted path of ydb-go-sdk metric or by name of
+// instrument it is reported as, like "db.client.connection.count".
+func (d metricDescriptions) override(path, name string) (MetricDescription, bool) {
+	if description, ok := d[path]; ok {
+		return description, true
+	}
+
+	description, ok := d[name]
+
+	return description, ok
+}
+
+// describe returns description and unit of metric with name of subsystem with dotted path.
+// Unknown metrics are described as "ydb-go-sdk <kind>" without unit.
+func (d metricDescriptions) describe(system, name, kind string) MetricDescription {
+	path := joinMetricPath(system, name)
+	if description, ok := d[path]; ok {
+		return description
+	}
+
+	if description, ok := metricsCatalog[path]; ok {
+		return description
+	}
+
+	if description, ok := metricsCatalogByName[name]; ok && system != "" {
+		return MetricDescription{
+			Description: fmt.Sprintf(description.Description, strings.TrimPrefix(system, "ydb.")),
+			Unit:        description.Unit,
+		}
+	}
+
+	return MetricDescription{
+		Description: "ydb-go-sdk " + kind,
+	}
+}
+
+func joinMetricPath(system, name string) string {
+	if system == "" {
+		return name
+	}
+
+	return system + "." + name
+}
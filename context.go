@@ -0,0 +1,115 @@
+package ydb
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ctxAttributesKey struct{}
+
+// ContextWithAttributes returns copy of ctx with attributes which are added to ydb-go-sdk spans,
+// span events and log records of operations executed with returned context.
+// Attributes are appended to attributes of parent context.
+func ContextWithAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, ctxAttributesKey{}, append(slices.Clip(attributesFromContext(ctx)), attrs...))
+}
+
+func attributesFromContext(ctx context.Context) []attribute.KeyValue {
+	if attrs, ok := ctx.Value(ctxAttributesKey{}).([]attribute.KeyValue); ok {
+		return attrs
+	}
+
+	return nil
+}
+
+type ctxDetailsKey struct{}
+
+// WithDetails returns copy of ctx which limits ydb-go-sdk events traced, logged and measured by
+// metrics of this package (like WithQueryOperationMetrics) for operations executed with returned
+// context. Details are intersected with details of parent context. Built-in ydb-go-sdk metrics
+// are not affected: ydb-go-sdk records them through metrics.Config without context.
+func WithDetails(ctx context.Context, details trace.Details) context.Context {
+	if parent, ok := detailsFromContext(ctx); ok {
+		details &= parent
+	}
+
+	return context.WithValue(ctx, ctxDetailsKey{}, details)
+}
+
+// WithoutTracing returns copy of ctx in which ydb-go-sdk operations create no spans, log records
+// and metrics of this package.
+func WithoutTracing(ctx context.Context) context.Context {
+	return WithDetails(ctx, 0)
+}
+
+func detailsFromContext(ctx context.Context) (trace.Details, bool) {
+	details, ok := ctx.Value(ctxDetailsKey{}).(trace.Details)
+
+	return details, ok
+}
+
+// enabledInContext reports whether events of group are enabled by per-call details of ctx.
+func enabledInContext(ctx context.Context, group trace.Details) bool {
+	details, ok := detailsFromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	return details&group != 0
+}
+
+// packageDetails maps ydb-go-sdk packages and log scopes to groups of events.
+var packageDetails = map[string]trace.Details{
+	"balancer":     trace.DriverEvents,
+	"conn":         trace.DriverEvents,
+	"coordination": trace.CoordinationEvents,
+	"credentials":  trace.DriverEvents,
+	"database":     trace.DatabaseSQLEvents,
+	"discovery":    trace.DiscoveryEvents,
+	"driver":       trace.DriverEvents,
+	"query":        trace.QueryEvents,
+	"ratelimiter":  trace.RatelimiterEvents,
+	"repeater":     trace.DriverEvents,
+	"retry":        trace.RetryEvents,
+	"scheme":       trace.SchemeEvents,
+	"scripting":    trace.ScriptingEvents,
+	"table":        trace.TableEvents,
+	"topic":        trace.TopicEvents,
+	"xsql":         trace.DatabaseSQLEvents,
+}
+
+// operationDetails returns group of events of ydb-go-sdk operation name, like
+// "github.com/ydb-platform/ydb-go-sdk/v3/internal/query.(*Client).Exec".
+// Unknown operations belong to all groups.
+func operationDetails(operationName string) trace.Details {
+	name := strings.TrimPrefix(strings.TrimPrefix(operationName, sdkInternalPrefix), sdkPrefix)
+	if i := strings.IndexAny(name, "./"); i >= 0 {
+		name = name[:i]
+	}
+
+	if details, ok := packageDetails[name]; ok {
+		return details
+	}
+
+	return trace.DetailsAll
+}
+
+// logNamesDetails returns group of events of ydb-go-sdk log scope like ["ydb", "query", "session"].
+// Unknown scopes belong to all groups.
+func logNamesDetails(names []string) trace.Details {
+	if len(names) > 1 {
+		if details, ok := packageDetails[names[1]]; ok {
+			return details
+		}
+	}
+
+	return trace.DetailsAll
+}
@@ -0,0 +1,106 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+)
+
+func TestContextWithAttributesAppendsToParent(t *testing.T) {
+	parent := ContextWithAttributes(context.Background(), attribute.String("tenant", "a"))
+	childA := ContextWithAttributes(parent, attribute.String("order.id", "1"))
+	childB := ContextWithAttributes(parent, attribute.String("order.id", "2"))
+
+	require.Len(t, attributesFromContext(parent), 1)
+	require.Equal(t, "1", attributesMap(attributesFromContext(childA))["order.id"].AsString())
+	require.Equal(t, "2", attributesMap(attributesFromContext(childB))["order.id"].AsString())
+}
+
+func TestContextAttributesOnSpansAndEvents(t *testing.T) {
+	a, recorder := newTestSpansAdapter()
+
+	ctx := ContextWithAttributes(context.Background(), attribute.String("order.id", "42"))
+	childCtx, s := a.Start(ctx, "query.Exec")
+	a.SpanFromContext(childCtx).Log("attempt")
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "42", spanAttributes(ended[0])["order.id"].AsString())
+	require.Len(t, ended[0].Events(), 1)
+	require.Equal(t, "42", attributesMap(ended[0].Events()[0].Attributes)["order.id"].AsString())
+}
+
+func TestLogAdapterAddsContextAttributes(t *testing.T) {
+	capture := &captureLogger{}
+	adapter := &logAdapter{logger: capture}
+
+	ctx := ContextWithAttributes(context.Background(),
+		attribute.String("order.id", "42"),
+		attribute.Int64Slice("shards", []int64{1, 2}),
+	)
+	adapter.Log(ctx, "hello")
+
+	require.Len(t, capture.records, 1)
+
+	attrs := map[string]otelLog.Value{}
+	capture.records[0].WalkAttributes(func(kv otelLog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+
+		return true
+	})
+	require.Equal(t, "42", attrs["order.id"].AsString())
+	require.Len(t, attrs["shards"].AsSlice(), 2)
+}
+
+func TestWithoutTracing(t *testing.T) {
+	a, recorder := newTestSpansAdapter()
+
+	parentCtx, parent := a.Start(context.Background(), "request")
+
+	ctx := WithoutTracing(parentCtx)
+	childCtx, s := a.Start(ctx, sdkInternalPrefix+"query.(*Client).Exec")
+	a.SpanFromContext(childCtx).Log("attempt")
+	s.End()
+	parent.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Empty(t, ended[0].Events())
+
+	capture := &captureLogger{}
+	(&logAdapter{logger: capture}).Log(ctx, "hello")
+	require.Empty(t, capture.records)
+}
+
+func TestWithDetails(t *testing.T) {
+	a, recorder := newTestSpansAdapter()
+
+	ctx := WithDetails(context.Background(), trace.QueryEvents)
+	_, query := a.Start(ctx, sdkInternalPrefix+"query.(*Client).Exec")
+	query.End()
+	_, conn := a.Start(ctx, sdkInternalPrefix+"conn.(*conn).Invoke")
+	conn.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, sdkInternalPrefix+"query.(*Client).Exec", ended[0].Name())
+
+	capture := &captureLogger{}
+	logger := &logAdapter{logger: capture}
+	logger.Log(log.WithNames(ctx, "ydb", "driver", "conn"), "dial")
+	logger.Log(log.WithNames(ctx, "ydb", "query", "session"), "create")
+	require.Len(t, capture.records, 1)
+	require.Equal(t, "create", capture.records[0].Body().AsString())
+}
+
+func TestOperationDetails(t *testing.T) {
+	require.Equal(t, trace.QueryEvents, operationDetails(sdkInternalPrefix+"query.(*Client).Exec"))
+	require.Equal(t, trace.RetryEvents, operationDetails(sdkPrefix+"retry.Retry"))
+	require.Equal(t, trace.DetailsAll, operationDetails("my-label"))
+}
@@ -0,0 +1,130 @@
+package ydb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	"go.opentelemetry.io/otel/attribute"
+	grpcCodes "google.golang.org/grpc/codes"
+)
+
+const (
+	cancelledAttribute         = "ydb.cancelled"
+	errorTypeAttribute         = "error.type"
+	deadlineRemainingAttribute = "ydb.deadline.remaining"
+	idempotentAttribute        = "ydb.idempotent"
+	retryDecisionAttribute     = "ydb.retry.decision"
+
+	cancelledErrorType        = "cancelled"
+	deadlineExceededErrorType = "deadline_exceeded"
+
+	retryDecisionRetry               = "retry"
+	retryDecisionRetryWithNewSession = "retry_with_new_session"
+	retryDecisionGiveUp              = "give_up"
+)
+
+// contextErrorKind classifies errors caused by caller context.
+type contextErrorKind int
+
+const (
+	notContextError contextErrorKind = iota
+	contextCanceled
+	contextDeadlineExceeded
+)
+
+// classifyContextError detects context cancellation and deadline including
+// errors wrapped by ydb-go-sdk and gRPC transport errors with the same meaning.
+func classifyContextError(err error) contextErrorKind {
+	switch {
+	case err == nil:
+		return notContextError
+	case errors.Is(err, context.DeadlineExceeded), ydb.IsTransportError(err, grpcCodes.DeadlineExceeded):
+		return contextDeadlineExceeded
+	case errors.Is(err, context.Canceled), ydb.IsTransportError(err, grpcCodes.Canceled):
+		return contextCanceled
+	default:
+		return notContextError
+	}
+}
+
+// contextErrorKindFromStatus classifies status label of ydb-go-sdk error metrics.
+func contextErrorKindFromStatus(status string) contextErrorKind {
+	switch status {
+	case "context/DeadlineExceeded", "transport/DeadlineExceeded":
+		return contextDeadlineExceeded
+	case "context/Canceled", "transport/Canceled":
+		return contextCanceled
+	default:
+		return notContextError
+	}
+}
+
+func (kind contextErrorKind) attributes() []attribute.KeyValue {
+	switch kind {
+	case contextCanceled:
+		return []attribute.KeyValue{
+			attribute.Bool(cancelledAttribute, true),
+			attribute.String(errorTypeAttribute, cancelledErrorType),
+		}
+	case contextDeadlineExceeded:
+		return []attribute.KeyValue{
+			attribute.String(errorTypeAttribute, deadlineExceededErrorType),
+		}
+	default:
+		return nil
+	}
+}
+
+// deadlineAttributes returns remaining deadline budget of ctx.
+func deadlineAttributes(ctx context.Context) []attribute.KeyValue {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+
+	return appendDurationAttributes(nil, deadlineRemainingAttribute, time.Until(deadline))
+}
+
+// retryDecisionFor returns decision of ydb-go-sdk retryer about error of operation.
+func retryDecisionFor(err error, idempotent bool) string {
+	switch {
+	case classifyContextError(err) != notContextError, !retry.Check(err).MustRetry(idempotent):
+		return retryDecisionGiveUp
+	case mustDeleteSession(err):
+		return retryDecisionRetryWithNewSession
+	default:
+		return retryDecisionRetry
+	}
+}
+
+// mustDeleteSession reports whether error invalidates table or query session.
+func mustDeleteSession(err error) bool {
+	if ydb.IsOperationError(err,
+		Ydb.StatusIds_BAD_SESSION,
+		Ydb.StatusIds_SESSION_BUSY,
+		Ydb.StatusIds_SESSION_EXPIRED,
+	) {
+		return true
+	}
+
+	return ydb.IsTransportError(err) && !ydb.IsTransportError(err,
+		grpcCodes.ResourceExhausted,
+		grpcCodes.OutOfRange,
+	)
+}
+
+// idempotentFromFields returns idempotency of operation from ydb-go-sdk span fields.
+func idempotentFromFields(fields []spans.KeyValue) (idempotent, ok bool) {
+	for _, field := range fields {
+		if field.Key() == "idempotent" && field.Type() == spans.BoolType {
+			return field.BoolValue(), true
+		}
+	}
+
+	return false, false
+}
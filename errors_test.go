@@ -0,0 +1,148 @@
+package ydb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestClassifyContextError(t *testing.T) {
+	require.Equal(t, notContextError, classifyContextError(nil))
+	require.Equal(t, notContextError, classifyContextError(errors.New("server error")))
+	require.Equal(t, contextCanceled, classifyContextError(fmt.Errorf("query: %w", context.Canceled)))
+	require.Equal(t, contextDeadlineExceeded, classifyContextError(fmt.Errorf("query: %w", context.DeadlineExceeded)))
+}
+
+func TestContextErrorKindFromStatus(t *testing.T) {
+	require.Equal(t, contextCanceled, contextErrorKindFromStatus("context/Canceled"))
+	require.Equal(t, contextDeadlineExceeded, contextErrorKindFromStatus("transport/DeadlineExceeded"))
+	require.Equal(t, notContextError, contextErrorKindFromStatus("OK"))
+}
+
+func TestSpanErrorContextCanceled(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		opts   []tracesOption
+		status codes.Code
+	}{
+		{
+			name:   "default",
+			status: codes.Unset,
+		},
+		{
+			name:   "error status",
+			opts:   []tracesOption{WithContextErrorStatus(codes.Error)},
+			status: codes.Error,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a, recorder := newTestSpansAdapter(tt.opts...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			_, s := a.Start(ctx, "query")
+			s.Error(fmt.Errorf("query: %w", context.Canceled))
+			s.End()
+
+			ended := recorder.Ended()
+			require.Len(t, ended, 1)
+			require.Equal(t, tt.status, ended[0].Status().Code)
+
+			attrs := spanAttributes(ended[0])
+			require.True(t, attrs[cancelledAttribute].AsBool())
+			require.Equal(t, cancelledErrorType, attrs[errorTypeAttribute].AsString())
+			require.Contains(t, attrs, attribute.Key(deadlineRemainingAttribute))
+		})
+	}
+}
+
+func TestSpanErrorServerFailure(t *testing.T) {
+	a, recorder := newTestSpansAdapter()
+
+	_, s := a.Start(context.Background(), "query")
+	s.Error(errors.New("server error"))
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, codes.Error, ended[0].Status().Code)
+	require.NotContains(t, spanAttributes(ended[0]), attribute.Key(cancelledAttribute))
+}
+
+func TestRetryDecisionFor(t *testing.T) {
+	require.Equal(t, retryDecisionGiveUp, retryDecisionFor(context.Canceled, true))
+	require.Equal(t, retryDecisionGiveUp, retryDecisionFor(errors.New("unknown"), true))
+}
+
+func TestSpanOKStatusAndRetryDecision(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithOKStatus(), WithRetryDecisions())
+
+	ctx, ok := a.Start(context.Background(), "retry.Do", log.Bool("idempotent", true))
+	_, failed := a.Start(ctx, "query.Exec")
+	a.SpanFromContext(ctx).Log("attempt")
+	failed.Error(errors.New("unknown"))
+	failed.End()
+	ok.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	require.Equal(t, codes.Error, ended[0].Status().Code)
+	require.Equal(t, retryDecisionGiveUp, spanAttributes(ended[0])[retryDecisionAttribute].AsString())
+	require.Equal(t, codes.Ok, ended[1].Status().Code)
+	require.True(t, spanAttributes(ended[1])[idempotentAttribute].AsBool())
+}
+
+func TestSpanOKStatusNotSetAfterParentSpanError(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithOKStatus())
+
+	ctx, s := a.Start(context.Background(), "stream")
+	a.SpanFromContext(ctx).Error(fmt.Errorf("recv: %w", context.Canceled))
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, codes.Unset, ended[0].Status().Code)
+}
+
+func TestRetryDecisionIdempotentInherited(t *testing.T) {
+	a := newAdapter(nil, WithRetryDecisions())
+
+	ctx, loop := a.start(context.Background(), "retry.Do", []spans.KeyValue{log.Bool("idempotent", true)})
+	sessionCtx, session := a.start(ctx, "query.Session.Begin", nil)
+	_, exec := a.start(sessionCtx, "query.Transaction.Exec", nil)
+
+	require.True(t, loop.state.retryLoop)
+	require.True(t, session.state.idempotent)
+	require.False(t, session.state.retryLoop)
+	require.True(t, exec.state.idempotent)
+
+	_, other := a.start(context.Background(), "query.Session.Exec", nil)
+	require.False(t, other.state.idempotent)
+}
+
+func TestRetryDecisionOfLoop(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithRetryDecisions())
+
+	ctx, succeeded := a.Start(context.Background(), "retry.Do", log.Bool("idempotent", true))
+	a.SpanFromContext(ctx).Error(errors.New("attempt"))
+	succeeded.End()
+
+	ctx, failed := a.Start(context.Background(), "retry.Do", log.Bool("idempotent", true))
+	a.SpanFromContext(ctx).Error(errors.New("attempt"))
+	failed.Error(errors.New("attempt"))
+	failed.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	require.NotContains(t, spanAttributes(ended[0]), attribute.Key(retryDecisionAttribute))
+	require.Equal(t, retryDecisionGiveUp, spanAttributes(ended[1])[retryDecisionAttribute].AsString())
+}
@@ -0,0 +1,97 @@
+package ydb
+
+import (
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	eventsDroppedAttribute         = "ydb.events.dropped"
+	eventsDroppedMessagesAttribute = "ydb.events.dropped.messages"
+	eventsDroppedCountsAttribute   = "ydb.events.dropped.counts"
+
+	// maxDroppedMessages limits number of distinct dropped messages counted separately, other
+	// dropped messages are counted in droppedOtherMessage bucket.
+	maxDroppedMessages  = 32
+	droppedOtherMessage = "other"
+)
+
+// eventsBudget limits number of events added to span by Log and aggregates
+// messages over the limit into counters.
+type eventsBudget struct {
+	limit int
+
+	mu      sync.Mutex
+	events  int
+	dropped map[string]int64
+	other   int64
+}
+
+func newEventsBudget(limit int) *eventsBudget {
+	return &eventsBudget{
+		limit: limit,
+	}
+}
+
+// allow reports whether event with msg fits into budget. Otherwise msg is counted as dropped.
+func (b *eventsBudget) allow(msg string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.events < b.limit {
+		b.events++
+
+		return true
+	}
+
+	if b.dropped == nil {
+		b.dropped = make(map[string]int64)
+	}
+
+	if _, ok := b.dropped[msg]; ok || len(b.dropped) < maxDroppedMessages {
+		b.dropped[msg]++
+	} else {
+		b.other++
+	}
+
+	return false
+}
+
+// attributes returns counters of dropped events.
+func (b *eventsBudget) attributes() []attribute.KeyValue {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.dropped) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(b.dropped)+1)
+	for msg := range b.dropped {
+		messages = append(messages, msg)
+	}
+
+	slices.Sort(messages)
+
+	var (
+		total  = b.other
+		counts = make([]int64, len(messages), len(messages)+1)
+	)
+	for i, msg := range messages {
+		counts[i] = b.dropped[msg]
+		total += counts[i]
+	}
+
+	if b.other > 0 {
+		messages = append(messages, droppedOtherMessage)
+		counts = append(counts, b.other)
+	}
+
+	return []attribute.KeyValue{
+		attribute.Int64(eventsDroppedAttribute, total),
+		attribute.StringSlice(eventsDroppedMessagesAttribute, messages),
+		attribute.Int64Slice(eventsDroppedCountsAttribute, counts),
+	}
+}
@@ -0,0 +1,71 @@
+package ydb
+
+import (
+	"path"
+
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// HistogramKind selects kinds of ydb-go-sdk histogram instruments.
+type HistogramKind int
+
+const (
+	// TimerHistograms are latency timers of ydb-go-sdk operations.
+	TimerHistograms HistogramKind = 1 << iota
+	// ValueHistograms are histograms of other values like numbers of attempts.
+	ValueHistograms
+
+	AllHistograms = TimerHistograms | ValueHistograms
+)
+
+// timerUnits are units of timer instruments.
+var timerUnits = map[string]bool{
+	string(TimerUnitSeconds):      true,
+	string(TimerUnitMilliseconds): true,
+	string(TimerUnitMicroseconds): true,
+}
+
+// ExponentialHistogramView returns view for OpenTelemetry SDK MeterProvider which selects base-2
+// exponential aggregation with maxSize buckets and maxScale for ydb-go-sdk histograms of kinds with
+// names matching glob pattern of path.Match, like "ydb_*". Empty pattern matches all names.
+// Only instruments of meter with instrumentation scope name are selected, empty scope means default
+// "ydb-go-sdk" scope of WithMetrics with nil meter. Kind of histogram is detected by unit: histograms
+// with time units of WithTimerUnit are timers. Explicit buckets of selected instruments are ignored.
+func ExponentialHistogramView(scope, pattern string, kinds HistogramKind, maxSize, maxScale int32) sdkMetric.View {
+	if scope == "" {
+		scope = instrumentationName
+	}
+
+	aggregation := sdkMetric.AggregationBase2ExponentialHistogram{
+		MaxSize:  maxSize,
+		MaxScale: maxScale,
+	}
+
+	return func(i sdkMetric.Instrument) (sdkMetric.Stream, bool) {
+		if i.Kind != sdkMetric.InstrumentKindHistogram || i.Scope.Name != scope {
+			return sdkMetric.Stream{}, false
+		}
+
+		if pattern != "" {
+			if matched, err := path.Match(pattern, i.Name); err != nil || !matched {
+				return sdkMetric.Stream{}, false
+			}
+		}
+
+		kind := ValueHistograms
+		if timerUnits[i.Unit] {
+			kind = TimerHistograms
+		}
+
+		if kinds&kind == 0 {
+			return sdkMetric.Stream{}, false
+		}
+
+		return sdkMetric.Stream{
+			Name:        i.Name,
+			Description: i.Description,
+			Unit:        i.Unit,
+			Aggregation: aggregation,
+		}, true
+	}
+}
@@ -0,0 +1,266 @@
+package ydb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// sdkPrefix is a common prefix of ydb-go-sdk public package paths.
+	sdkPrefix = "github.com/ydb-platform/ydb-go-sdk/v3/"
+	// sdkInternalPrefix is a common prefix of ydb-go-sdk operation names.
+	sdkInternalPrefix = sdkPrefix + "internal/"
+)
+
+var (
+	_ spans.Adapter = (*fanOutAdapter)(nil)
+	_ spans.Span    = (*fanOutSpan)(nil)
+)
+
+// TracerRoute routes ydb-go-sdk spans to tracer by operation name prefixes.
+type TracerRoute struct {
+	tracer   otelTrace.Tracer
+	prefixes []string
+}
+
+// RouteTracer returns route of spans with operation names starting with one of prefixes to tracer.
+// Prefixes are matched both with full operation name and with operation name without
+// "github.com/ydb-platform/ydb-go-sdk/v3/internal/" prefix, so "query." and "topic" are valid prefixes.
+// Route without prefixes receives all spans.
+// If tracer is nil, otel.Tracer("ydb-go-sdk") is used.
+func RouteTracer(tracer otelTrace.Tracer, prefixes ...string) TracerRoute {
+	return TracerRoute{
+		tracer:   tracer,
+		prefixes: append([]string(nil), prefixes...),
+	}
+}
+
+type fanOutMember struct {
+	adapter  *adapter
+	prefixes []string
+}
+
+func (m *fanOutMember) matches(operationName string) bool {
+	if len(m.prefixes) == 0 {
+		return true
+	}
+
+	shortName := strings.TrimPrefix(operationName, sdkInternalPrefix)
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(operationName, prefix) || strings.HasPrefix(shortName, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type fanOutAdapter struct {
+	members []*fanOutMember
+}
+
+type fanOutCtxKey struct{}
+
+// FanOutSpansAdapter returns spans.Adapter which starts each ydb-go-sdk span in all tracers
+// with matching routes. The span of the first matching route is the primary one: it is put
+// into context and other member spans are linked to it. Nested spans of each tracer are
+// parented by spans of the same tracer. If parent operation was not routed to tracer, nested span
+// of tracer is a new root linked to the primary span.
+func FanOutSpansAdapter(routes []TracerRoute, opts ...tracesOption) spans.Adapter {
+	if len(routes) == 0 {
+		routes = []TracerRoute{RouteTracer(nil)}
+	}
+
+	a := &fanOutAdapter{
+		members: make([]*fanOutMember, len(routes)),
+	}
+	for i, route := range routes {
+		a.members[i] = &fanOutMember{
+			adapter:  newAdapter(route.tracer, opts...),
+			prefixes: route.prefixes,
+		}
+	}
+
+	return a
+}
+
+// WithFanOutTracers enables ydb-go-sdk spans export into several tracers via OpenTelemetry.
+func WithFanOutTracers(routes []TracerRoute, opts ...tracesOption) ydb.Option {
+	a, _ := FanOutSpansAdapter(routes, opts...).(*fanOutAdapter)
+
+	adapters := make([]*adapter, len(a.members))
+	for i, member := range a.members {
+		adapters[i] = member.adapter
+	}
+
+	return withAdapterTraces(spans.WithTraces(a), adapters...)
+}
+
+func (a *fanOutAdapter) Details() trace.Details {
+	return a.members[0].adapter.Details()
+}
+
+func (a *fanOutAdapter) SpanFromContext(ctx context.Context) spans.Span {
+	if parent := fanOutSpanFromContext(ctx); parent != nil {
+		return parent.fromContext()
+	}
+
+	return a.members[0].adapter.SpanFromContext(ctx)
+}
+
+func (a *fanOutAdapter) Start(ctx context.Context, operationName string, fields ...spans.KeyValue) (
+	context.Context, spans.Span,
+) {
+	var (
+		parent   = fanOutSpanFromContext(ctx)
+		childCtx context.Context
+		child    = &fanOutSpan{
+			spans: make([]*span, len(a.members)),
+		}
+	)
+
+	for i, member := range a.members {
+		if !member.matches(operationName) {
+			continue
+		}
+
+		var (
+			memberCtx = ctx
+			opts      []otelTrace.SpanStartOption
+		)
+		if parent != nil {
+			if parent.spans[i] != nil {
+				memberCtx = otelTrace.ContextWithSpan(ctx, parent.spans[i].span)
+			} else {
+				// ctx carries span of another tracer, so span of member is a new root linked
+				// to the primary span instead
+				opts = append(opts, otelTrace.WithNewRoot())
+				if child.primary == nil {
+					opts = append(opts, otelTrace.WithLinks(otelTrace.Link{
+						SpanContext: parent.primary.span.SpanContext(),
+					}))
+				}
+			}
+		}
+
+		if child.primary == nil {
+			childCtx, child.primary = member.adapter.start(memberCtx, operationName, fields, opts...)
+			child.spans[i] = child.primary
+
+			continue
+		}
+
+		opts = append(opts, otelTrace.WithLinks(otelTrace.Link{SpanContext: child.primary.span.SpanContext()}))
+		_, child.spans[i] = member.adapter.start(memberCtx, operationName, fields, opts...)
+	}
+
+	if child.primary == nil {
+		return ctx, &span{
+			span:    nonRecordingSpan(ctx),
+			adapter: a.members[0].adapter,
+		}
+	}
+
+	return context.WithValue(childCtx, fanOutCtxKey{}, child), child
+}
+
+// fanOutSpanFromContext returns fan-out span if it is the current span of ctx.
+func fanOutSpanFromContext(ctx context.Context) *fanOutSpan {
+	s, ok := ctx.Value(fanOutCtxKey{}).(*fanOutSpan)
+	if !ok || s.primary.span != otelTrace.SpanFromContext(ctx) {
+		return nil
+	}
+
+	return s
+}
+
+// fanOutSpan is a set of member spans of the same ydb-go-sdk operation.
+type fanOutSpan struct {
+	primary *span
+	// spans are aligned with fan-out adapter members, nil for members without matching route
+	spans []*span
+}
+
+// fromContext returns fan-out span with the same member spans which are not marked as started,
+// like spans returned by SpanFromContext of adapter.
+func (s *fanOutSpan) fromContext() *fanOutSpan {
+	result := &fanOutSpan{
+		spans: make([]*span, len(s.spans)),
+	}
+	for i, ms := range s.spans {
+		if ms == nil {
+			continue
+		}
+
+		fromContext := *ms
+		fromContext.started = false
+		result.spans[i] = &fromContext
+		if ms == s.primary {
+			result.primary = &fromContext
+		}
+	}
+
+	return result
+}
+
+// memberSpan returns span of member or primary span if operation was not routed to member.
+func (s *fanOutSpan) memberSpan(member *adapter) *span {
+	for _, ms := range s.spans {
+		if ms != nil && ms.adapter == member {
+			return ms
+		}
+	}
+
+	return s.primary
+}
+
+func (s *fanOutSpan) each(f func(s *span)) {
+	for _, ms := range s.spans {
+		if ms != nil {
+			f(ms)
+		}
+	}
+}
+
+func (s *fanOutSpan) ID() (string, bool) {
+	return s.primary.ID()
+}
+
+func (s *fanOutSpan) TraceID() (string, bool) {
+	return s.primary.TraceID()
+}
+
+func (s *fanOutSpan) Link(link spans.Span, fields ...spans.KeyValue) {
+	s.each(func(ms *span) {
+		ms.Link(link, fields...)
+	})
+}
+
+func (s *fanOutSpan) Log(msg string, fields ...spans.KeyValue) {
+	s.each(func(ms *span) {
+		ms.Log(msg, fields...)
+	})
+}
+
+func (s *fanOutSpan) Warn(err error, fields ...spans.KeyValue) {
+	s.each(func(ms *span) {
+		ms.Warn(err, fields...)
+	})
+}
+
+func (s *fanOutSpan) Error(err error, fields ...spans.KeyValue) {
+	s.each(func(ms *span) {
+		ms.Error(err, fields...)
+	})
+}
+
+func (s *fanOutSpan) End(fields ...spans.KeyValue) {
+	s.each(func(ms *span) {
+		ms.End(fields...)
+	})
+}
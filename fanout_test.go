@@ -0,0 +1,123 @@
+package ydb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestFanOutSpansAdapterRoutesAndParents(t *testing.T) {
+	var (
+		recorderA = tracetest.NewSpanRecorder()
+		recorderB = tracetest.NewSpanRecorder()
+		tracerA   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderA)).Tracer("a")
+		tracerB   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderB)).Tracer("b")
+	)
+
+	a := FanOutSpansAdapter([]TracerRoute{
+		RouteTracer(tracerA),
+		RouteTracer(tracerB, "query."),
+	})
+
+	ctx, doSpan := a.Start(context.Background(), sdkInternalPrefix+"query.(*Client).Do")
+	_, execSpan := a.Start(ctx, sdkInternalPrefix+"query.(*Session).Exec")
+	_, connSpan := a.Start(ctx, sdkInternalPrefix+"conn.(*conn).Invoke")
+	a.SpanFromContext(ctx).Log("attempt")
+	connSpan.End()
+	execSpan.End()
+	doSpan.End()
+
+	endedA := recorderA.Ended()
+	endedB := recorderB.Ended()
+	require.Len(t, endedA, 3)
+	require.Len(t, endedB, 2)
+
+	doA, doB := endedA[2], endedB[1]
+	require.Len(t, doA.Events(), 1)
+	require.Len(t, doB.Events(), 1)
+	require.Len(t, doB.Links(), 1)
+	require.Equal(t, doA.SpanContext().SpanID(), doB.Links()[0].SpanContext.SpanID())
+
+	require.Equal(t, doA.SpanContext().SpanID(), endedA[0].Parent().SpanID())
+	require.Equal(t, doA.SpanContext().SpanID(), endedA[1].Parent().SpanID())
+	require.Equal(t, doB.SpanContext().SpanID(), endedB[0].Parent().SpanID())
+}
+
+func TestFanOutSpansAdapterNewRootWithoutMemberParent(t *testing.T) {
+	var (
+		recorderA = tracetest.NewSpanRecorder()
+		recorderB = tracetest.NewSpanRecorder()
+		tracerA   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderA)).Tracer("a")
+		tracerB   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderB)).Tracer("b")
+	)
+
+	a := FanOutSpansAdapter([]TracerRoute{
+		RouteTracer(tracerA),
+		RouteTracer(tracerB, "query."),
+	})
+
+	ctx, connSpan := a.Start(context.Background(), sdkInternalPrefix+"conn.(*conn).Invoke")
+	_, execSpan := a.Start(ctx, sdkInternalPrefix+"query.(*Session).Exec")
+	execSpan.End()
+	connSpan.End()
+
+	endedA := recorderA.Ended()
+	endedB := recorderB.Ended()
+	require.Len(t, endedA, 2)
+	require.Len(t, endedB, 1)
+
+	connA, execA, execB := endedA[1], endedA[0], endedB[0]
+	require.Equal(t, connA.SpanContext().SpanID(), execA.Parent().SpanID())
+	require.False(t, execB.Parent().IsValid())
+	require.NotEqual(t, connA.SpanContext().TraceID(), execB.SpanContext().TraceID())
+	require.Len(t, execB.Links(), 1)
+	require.Equal(t, execA.SpanContext().SpanID(), execB.Links()[0].SpanContext.SpanID())
+}
+
+func TestFanOutSpansAdapterPrimaryNewRoot(t *testing.T) {
+	var (
+		recorderA = tracetest.NewSpanRecorder()
+		recorderB = tracetest.NewSpanRecorder()
+		tracerA   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderA)).Tracer("a")
+		tracerB   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderB)).Tracer("b")
+	)
+
+	a := FanOutSpansAdapter([]TracerRoute{
+		RouteTracer(tracerA, "conn."),
+		RouteTracer(tracerB, "query."),
+	})
+
+	ctx, connSpan := a.Start(context.Background(), sdkInternalPrefix+"conn.(*conn).Invoke")
+	_, execSpan := a.Start(ctx, sdkInternalPrefix+"query.(*Session).Exec")
+	execSpan.End()
+	connSpan.End()
+
+	connA, execB := recorderA.Ended()[0], recorderB.Ended()[0]
+	require.False(t, execB.Parent().IsValid())
+	require.Len(t, execB.Links(), 1)
+	require.Equal(t, connA.SpanContext().SpanID(), execB.Links()[0].SpanContext.SpanID())
+}
+
+func TestFanOutSpansAdapterRetryLoopDecision(t *testing.T) {
+	var (
+		recorderA = tracetest.NewSpanRecorder()
+		recorderB = tracetest.NewSpanRecorder()
+		tracerA   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderA)).Tracer("a")
+		tracerB   = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorderB)).Tracer("b")
+	)
+
+	a := FanOutSpansAdapter([]TracerRoute{RouteTracer(tracerA), RouteTracer(tracerB)}, WithRetryDecisions())
+
+	ctx, loop := a.Start(context.Background(), "retry.Do", log.Bool("idempotent", true))
+	a.SpanFromContext(ctx).Error(errors.New("attempt"))
+	loop.End()
+
+	require.NotContains(t, spanAttributes(recorderA.Ended()[0]), attribute.Key(retryDecisionAttribute))
+	require.NotContains(t, spanAttributes(recorderB.Ended()[0]), attribute.Key(retryDecisionAttribute))
+}
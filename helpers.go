@@ -1,36 +1,262 @@
 package ydb
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"time"
 
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
 	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func fieldToAttribute(field spans.KeyValue) attribute.KeyValue {
+const (
+	nilValue = "<nil>"
+
+	// maxMapDepth limits flattening of nested maps, deeper values (like self-referencing maps) are
+	// recorded as maxDepthValue.
+	maxMapDepth   = 8
+	maxDepthValue = "<max depth>"
+)
+
+// attributeConverter converts value of registered custom type into span attributes.
+type attributeConverter func(key string, value any) []attribute.KeyValue
+
+// attributeConverters is a registry of custom type converters keyed by value type.
+type attributeConverters map[reflect.Type]attributeConverter
+
+func (cc attributeConverters) fieldToAttributes(attrs []attribute.KeyValue, field spans.KeyValue) []attribute.KeyValue {
 	switch field.Type() {
 	case spans.IntType:
-		return attribute.Int(field.Key(), field.IntValue())
+		return append(attrs, attribute.Int(field.Key(), field.IntValue()))
 	case spans.Int64Type:
-		return attribute.Int64(field.Key(), field.Int64Value())
+		return append(attrs, attribute.Int64(field.Key(), field.Int64Value()))
 	case spans.StringType:
-		return attribute.String(field.Key(), field.StringValue())
+		return append(attrs, attribute.String(field.Key(), field.StringValue()))
 	case spans.BoolType:
-		return attribute.Bool(field.Key(), field.BoolValue())
+		return append(attrs, attribute.Bool(field.Key(), field.BoolValue()))
 	case spans.StringsType:
-		return attribute.StringSlice(field.Key(), field.StringsValue())
-	case spans.StringerType:
-		return attribute.Stringer(field.Key(), field.Stringer())
+		return append(attrs, attribute.StringSlice(field.Key(), field.StringsValue()))
+	case log.DurationType:
+		return appendDurationAttributes(attrs, field.Key(), field.DurationValue())
+	case log.ErrorType:
+		return appendErrorAttributes(attrs, field.Key(), field.ErrorValue())
 	default:
-		return attribute.String(field.Key(), fmt.Sprintf("%v", field.AnyValue()))
+		return cc.valueToAttributes(attrs, field.Key(), field.AnyValue())
 	}
 }
 
-func fieldsToAttributes(fields []spans.KeyValue) []attribute.KeyValue {
+func (cc attributeConverters) fieldsToAttributes(fields []spans.KeyValue) []attribute.KeyValue {
 	attributes := make([]attribute.KeyValue, 0, len(fields))
 	for _, kv := range fields {
-		attributes = append(attributes, fieldToAttribute(kv))
+		attributes = cc.fieldToAttributes(attributes, kv)
 	}
 
 	return attributes
 }
+
+func (cc attributeConverters) valueToAttributes(attrs []attribute.KeyValue, key string, value any) []attribute.KeyValue {
+	return cc.appendValueAttributes(attrs, key, value, 0)
+}
+
+//nolint:gocyclo,funlen
+func (cc attributeConverters) appendValueAttributes(
+	attrs []attribute.KeyValue, key string, value any, depth int,
+) []attribute.KeyValue {
+	if value == nil {
+		return append(attrs, attribute.String(key, nilValue))
+	}
+
+	if convert, ok := cc[reflect.TypeOf(value)]; ok {
+		return append(attrs, convert(key, value)...)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return append(attrs, attribute.String(key, v))
+	case bool:
+		return append(attrs, attribute.Bool(key, v))
+	case int:
+		return append(attrs, attribute.Int(key, v))
+	case int8, int16, int32:
+		return append(attrs, attribute.Int64(key, reflect.ValueOf(v).Int()))
+	case uint8, uint16, uint32:
+		return append(attrs, attribute.Int64(key, int64(reflect.ValueOf(v).Uint())))
+	case uint, uint64, uintptr:
+		return append(attrs, attribute.Int64(key, saturatedInt64(reflect.ValueOf(v).Uint())))
+	case int64:
+		return append(attrs, attribute.Int64(key, v))
+	case float32:
+		return append(attrs, attribute.Float64(key, float64(v)))
+	case float64:
+		return append(attrs, attribute.Float64(key, v))
+	case time.Duration:
+		return appendDurationAttributes(attrs, key, v)
+	case []string:
+		return append(attrs, attribute.StringSlice(key, v))
+	case []bool:
+		return append(attrs, attribute.BoolSlice(key, v))
+	case []int:
+		return append(attrs, attribute.IntSlice(key, v))
+	case []int32:
+		return append(attrs, attribute.Int64Slice(key, toInt64s(v)))
+	case []int64:
+		return append(attrs, attribute.Int64Slice(key, v))
+	case []uint32:
+		return append(attrs, attribute.Int64Slice(key, toInt64s(v)))
+	case []uint64:
+		return append(attrs, attribute.Int64Slice(key, saturatedInt64s(v)))
+	case []uint:
+		return append(attrs, attribute.Int64Slice(key, saturatedInt64s(v)))
+	case []float32:
+		return append(attrs, attribute.Float64Slice(key, toFloat64s(v)))
+	case []float64:
+		return append(attrs, attribute.Float64Slice(key, v))
+	case error:
+		return appendErrorAttributes(attrs, key, v)
+	case fmt.Stringer:
+		return append(attrs, attribute.String(key, safeStringer(v)))
+	}
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Map {
+		if depth >= maxMapDepth {
+			return append(attrs, attribute.String(key, maxDepthValue))
+		}
+
+		return cc.appendMapAttributes(attrs, key, rv, depth+1)
+	}
+
+	return append(attrs, attribute.String(key, fmt.Sprintf("%v", value)))
+}
+
+// appendMapAttributes flattens map entries into attributes with dotted keys.
+func (cc attributeConverters) appendMapAttributes(
+	attrs []attribute.KeyValue, key string, m reflect.Value, depth int,
+) []attribute.KeyValue {
+	if m.IsNil() {
+		return append(attrs, attribute.String(key, nilValue))
+	}
+
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+
+	entries := make([]entry, 0, m.Len())
+	for iter := m.MapRange(); iter.Next(); {
+		entries = append(entries, entry{
+			key:   fmt.Sprint(iter.Key().Interface()),
+			value: iter.Value(),
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	for _, e := range entries {
+		attrs = cc.appendValueAttributes(attrs, key+"."+e.key, e.value.Interface(), depth)
+	}
+
+	return attrs
+}
+
+// appendDurationAttributes records duration as seconds for querying and as string for reading.
+func appendDurationAttributes(attrs []attribute.KeyValue, key string, d time.Duration) []attribute.KeyValue {
+	return append(attrs,
+		attribute.Float64(key, d.Seconds()),
+		attribute.String(key+".string", d.String()),
+	)
+}
+
+// appendErrorAttributes records error message and type of the outermost error.
+func appendErrorAttributes(attrs []attribute.KeyValue, key string, err error) []attribute.KeyValue {
+	if err == nil {
+		return append(attrs, attribute.String(key, ""))
+	}
+
+	return append(attrs,
+		attribute.String(key, safeError(err)),
+		attribute.String(key+".type", errorType(err)),
+	)
+}
+
+// errorType returns type name of error skipping fmt.Errorf wrappers which carry no type information.
+func errorType(err error) string {
+	typeName := fmt.Sprintf("%T", err)
+	if typeName == "*fmt.wrapError" {
+		if unwrapped := errors.Unwrap(err); unwrapped != nil {
+			return errorType(unwrapped)
+		}
+	}
+
+	return typeName
+}
+
+// safeStringer returns string representation of stringer which tolerates typed nil pointers.
+func safeStringer(s fmt.Stringer) string {
+	if s == nil {
+		return nilValue
+	}
+
+	if v := reflect.ValueOf(s); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nilValue
+	}
+
+	return s.String()
+}
+
+// safeError returns message of error which tolerates typed nil pointers and panics of Error method.
+func safeError(err error) (message string) {
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nilValue
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			message = fmt.Sprintf("<panic: %v>", r)
+		}
+	}()
+
+	return err.Error()
+}
+
+// saturatedInt64 converts unsigned value into int64 saturating at math.MaxInt64.
+func saturatedInt64(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return int64(v)
+}
+
+func saturatedInt64s[T uint | uint64](values []T) []int64 {
+	result := make([]int64, len(values))
+	for i, v := range values {
+		result[i] = saturatedInt64(uint64(v))
+	}
+
+	return result
+}
+
+func toInt64s[T int32 | uint32](values []T) []int64 {
+	result := make([]int64, len(values))
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+
+	return result
+}
+
+func toFloat64s(values []float32) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = float64(v)
+	}
+
+	return result
+}
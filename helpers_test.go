@@ -0,0 +1,136 @@
+package ydb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type nilStringer struct{}
+
+func (*nilStringer) String() string {
+	panic("must not be called")
+}
+
+type nilError struct{}
+
+func (*nilError) Error() string {
+	panic("must not be called")
+}
+
+type customValue struct {
+	id int
+}
+
+func attributesMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, attr := range attrs {
+		m[attr.Key] = attr.Value
+	}
+
+	return m
+}
+
+func TestFieldsToAttributesDuration(t *testing.T) {
+	attrs := attributesMap(attributeConverters(nil).fieldsToAttributes([]spans.KeyValue{
+		log.Duration("latency", 1500*time.Millisecond),
+	}))
+
+	require.InDelta(t, 1.5, attrs["latency"].AsFloat64(), 1e-9)
+	require.Equal(t, "1.5s", attrs["latency.string"].AsString())
+}
+
+func TestFieldsToAttributesError(t *testing.T) {
+	attrs := attributesMap(attributeConverters(nil).fieldsToAttributes([]spans.KeyValue{
+		log.Error(fmt.Errorf("wrapped: %w", errors.ErrUnsupported)),
+	}))
+
+	require.Equal(t, "wrapped: unsupported operation", attrs["error"].AsString())
+	require.Equal(t, "*errors.errorString", attrs["error.type"].AsString())
+}
+
+func TestFieldsToAttributesAnyValues(t *testing.T) {
+	attrs := attributesMap(attributeConverters(nil).fieldsToAttributes([]spans.KeyValue{
+		log.Any("ints", []int{1, 2, 3}),
+		log.Any("floats", []float64{0.5}),
+		log.Any("stringer", (*nilStringer)(nil)),
+		log.Any("labels", map[string]any{
+			"node": 1,
+			"nested": map[string]string{
+				"dc": "a",
+			},
+		}),
+	}))
+
+	require.Equal(t, []int64{1, 2, 3}, attrs["ints"].AsInt64Slice())
+	require.Equal(t, []float64{0.5}, attrs["floats"].AsFloat64Slice())
+	require.Equal(t, nilValue, attrs["stringer"].AsString())
+	require.Equal(t, int64(1), attrs["labels.node"].AsInt64())
+	require.Equal(t, "a", attrs["labels.nested.dc"].AsString())
+}
+
+func TestWithAttributeConverter(t *testing.T) {
+	a := &adapter{}
+	WithAttributeConverter(func(key string, value customValue) []attribute.KeyValue {
+		return []attribute.KeyValue{attribute.Int(key+".id", value.id)}
+	}).applyTracesOption(a)
+
+	attrs := attributesMap(a.converters.fieldsToAttributes([]spans.KeyValue{
+		log.Any("custom", customValue{id: 42}),
+	}))
+
+	require.Equal(t, int64(42), attrs["custom.id"].AsInt64())
+}
+
+func TestFieldsToAttributesSelfReferencingMap(t *testing.T) {
+	m := map[string]any{"id": 1}
+	m["self"] = m
+
+	attrs := attributesMap(attributeConverters(nil).fieldsToAttributes([]spans.KeyValue{
+		log.Any("m", m),
+	}))
+
+	require.Equal(t, int64(1), attrs["m.id"].AsInt64())
+	require.Equal(t, int64(1), attrs["m.self.self.id"].AsInt64())
+
+	key := "m"
+	for range maxMapDepth {
+		key += ".self"
+	}
+	require.Equal(t, maxDepthValue, attrs[attribute.Key(key)].AsString())
+}
+
+func TestFieldsToAttributesTypedNilError(t *testing.T) {
+	var err *nilError
+
+	attrs := attributesMap(attributeConverters(nil).fieldsToAttributes([]spans.KeyValue{
+		log.Error(err),
+		log.Any("any", err),
+	}))
+
+	require.Equal(t, nilValue, attrs["error"].AsString())
+	require.Equal(t, "*ydb.nilError", attrs["error.type"].AsString())
+	require.Equal(t, nilValue, attrs["any"].AsString())
+}
+
+func TestFieldsToAttributesUnsigned(t *testing.T) {
+	attrs := attributesMap(attributeConverters(nil).fieldsToAttributes([]spans.KeyValue{
+		log.Any("small", uint64(42)),
+		log.Any("huge", uint64(math.MaxUint64)),
+		log.Any("uint", uint(7)),
+		log.Any("slice", []uint64{1, math.MaxUint64}),
+	}))
+
+	require.Equal(t, attribute.INT64, attrs["small"].Type())
+	require.Equal(t, int64(42), attrs["small"].AsInt64())
+	require.Equal(t, int64(math.MaxInt64), attrs["huge"].AsInt64())
+	require.Equal(t, int64(7), attrs["uint"].AsInt64())
+	require.Equal(t, []int64{1, math.MaxInt64}, attrs["slice"].AsInt64Slice())
+}
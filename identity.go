@@ -0,0 +1,76 @@
+package ydb
+
+import (
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	driverNameAttribute    = "ydb.driver.name"
+	dbNamespaceAttribute   = "db.namespace"
+	serverAddressAttribute = "server.address"
+	serverPortAttribute    = "server.port"
+)
+
+// driverIdentity holds attributes of ydb-go-sdk driver instance which are attached to all signals.
+// Name is known in advance, database and endpoint are detected on driver init.
+type driverIdentity struct {
+	attrs atomic.Pointer[[]attribute.KeyValue]
+	name  string
+}
+
+func newDriverIdentity(name string) *driverIdentity {
+	d := &driverIdentity{name: name}
+	d.attrs.Store(&[]attribute.KeyValue{
+		attribute.String(driverNameAttribute, name),
+	})
+
+	return d
+}
+
+// attributes returns identity attributes, nil for nil identity.
+func (d *driverIdentity) attributes() []attribute.KeyValue {
+	if attrs := d.snapshot(); attrs != nil {
+		return *attrs
+	}
+
+	return nil
+}
+
+// snapshot returns current identity attributes. Snapshot changes once driver init is detected,
+// so it can be used as a part of cache keys.
+func (d *driverIdentity) snapshot() *[]attribute.KeyValue {
+	if d == nil {
+		return nil
+	}
+
+	return d.attrs.Load()
+}
+
+// driverTrace returns driver trace which detects database and endpoint of driver.
+func (d *driverIdentity) driverTrace() trace.Driver {
+	return trace.Driver{
+		OnInit: func(info trace.DriverInitStartInfo) func(trace.DriverInitDoneInfo) {
+			attrs := []attribute.KeyValue{
+				attribute.String(driverNameAttribute, d.name),
+				attribute.String(dbNamespaceAttribute, info.Database),
+			}
+			host, port, err := net.SplitHostPort(info.Endpoint)
+			if err != nil {
+				host = info.Endpoint
+			}
+			attrs = append(attrs, attribute.String(serverAddressAttribute, host))
+			if p, err := strconv.Atoi(port); err == nil {
+				attrs = append(attrs, attribute.Int(serverPortAttribute, p))
+			}
+
+			d.attrs.Store(&attrs)
+
+			return nil
+		},
+	}
+}
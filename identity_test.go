@@ -0,0 +1,129 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func initDriver(identity *driverIdentity, endpoint, database string) {
+	identity.driverTrace().OnInit(trace.DriverInitStartInfo{
+		Endpoint: endpoint,
+		Database: database,
+	})
+}
+
+func TestDriverIdentitySpans(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithDriverName("orders"))
+
+	_, before := a.Start(context.Background(), "before")
+	before.End()
+
+	initDriver(a.identity, "ydb.example.com:2135", "/local/orders")
+
+	_, after := a.Start(context.Background(), "after")
+	after.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+
+	attrs := spanAttributes(ended[0])
+	require.Equal(t, "orders", attrs[driverNameAttribute].AsString())
+	require.NotContains(t, attrs, attribute.Key(dbNamespaceAttribute))
+
+	attrs = spanAttributes(ended[1])
+	require.Equal(t, "orders", attrs[driverNameAttribute].AsString())
+	require.Equal(t, "/local/orders", attrs[dbNamespaceAttribute].AsString())
+	require.Equal(t, "ydb.example.com", attrs[serverAddressAttribute].AsString())
+	require.Equal(t, int64(2135), attrs[serverPortAttribute].AsInt64())
+}
+
+func TestDriverIdentityMetricsSharedMeter(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	meter := provider.Meter("test")
+
+	for i, name := range []string{"orders", "billing"} {
+		cfg, _ := metricsConfigFromOpts(meter, WithDriverName(name)).(*metricsConfig)
+		initDriver(cfg.identity, "localhost:2136", "/local/"+name)
+
+		gauge := cfg.WithSystem("ydb").GaugeVec("sessions", "node_id").With(map[string]string{"node_id": "1"})
+		gauge.Set(float64(10 * (i + 1)))
+		gauge.Set(float64(5 * (i + 1)))
+	}
+
+	sum, ok := collectMetrics(t, reader)["ydb_sessions"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 2)
+
+	values := map[string]float64{}
+	for _, dp := range sum.DataPoints {
+		name, _ := dp.Attributes.Value(driverNameAttribute)
+		namespace, _ := dp.Attributes.Value(dbNamespaceAttribute)
+		require.Equal(t, "/local/"+name.AsString(), namespace.AsString())
+		values[name.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]float64{"orders": 5, "billing": 10}, values)
+}
+
+func TestDriverIdentityGaugeSeriesCreatedBeforeInit(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg, _ := metricsConfigFromOpts(provider.Meter("test"), WithDriverName("orders")).(*metricsConfig)
+	vec := cfg.WithSystem("ydb").GaugeVec("sessions", "node_id")
+
+	vec.With(map[string]string{"node_id": "1"}).Add(2)
+	initDriver(cfg.identity, "localhost:2136", "/local/orders")
+	vec.With(map[string]string{"node_id": "1"}).Add(3)
+
+	gauge, ok := collectMetrics(t, reader)["ydb_sessions"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	require.InDelta(t, 5.0, gauge.DataPoints[0].Value, 0)
+
+	namespace, _ := gauge.DataPoints[0].Attributes.Value(dbNamespaceAttribute)
+	require.Equal(t, "/local/orders", namespace.AsString())
+}
+
+func TestDriverIdentityCounterOverflowSeries(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	opts := []metricsOption{WithDriverName("orders"), WithCardinalityLimit(1)}
+	cfg, _ := metricsConfigFromOpts(provider.Meter("test"), opts...).(*metricsConfig)
+	initDriver(cfg.identity, "localhost:2136", "/local/orders")
+
+	errs := cfg.WithSystem("ydb").CounterVec("errs", "status")
+	errs.With(map[string]string{"status": "OK"}).Inc()
+	errs.With(map[string]string{"status": "context/Canceled"}).Inc()
+
+	sum, ok := collectMetrics(t, reader)["ydb_errs"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 2)
+
+	for _, dp := range sum.DataPoints {
+		namespace, _ := dp.Attributes.Value(dbNamespaceAttribute)
+		require.Equal(t, "/local/orders", namespace.AsString())
+	}
+}
+
+func TestDriverIdentityLogs(t *testing.T) {
+	capture := &captureLogger{}
+	cfg := loggerConfigFrom(capture, WithDriverName("orders"))
+	initDriver(cfg.identity, "localhost", "/local")
+
+	(&logAdapter{logger: cfg.logger, identity: cfg.identity}).Log(context.Background(), "hello")
+
+	require.Len(t, capture.records, 1)
+
+	attrs := map[string]string{}
+	capture.records[0].WalkAttributes(func(kv otelLog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+
+		return true
+	})
+	require.Equal(t, "orders", attrs[driverNameAttribute])
+	require.Equal(t, "/local", attrs[dbNamespaceAttribute])
+	require.Equal(t, "localhost", attrs[serverAddressAttribute])
+}
@@ -74,9 +74,10 @@ func main() {
 	}()
 
 	var (
-		tracer = otel.Tracer("main")
-		ctx    context.Context
-		cancel context.CancelFunc
+		tracer   = otel.Tracer("main")
+		appSpans = ydbOtel.NewSpanStarter(tracer)
+		ctx      context.Context
+		cancel   context.CancelFunc
 	)
 	if *stopAfter == 0 {
 		ctx, cancel = context.WithCancel(context.Background())
@@ -106,14 +107,14 @@ func main() {
 		log.Println(err)
 	}
 
-	_, err = selectSession(ctx, tracer, db.Query(), path.Join(db.Name(), "/"),
+	_, err = selectSession(ctx, appSpans, db.Query(), path.Join(db.Name(), "/"),
 		rand.Int63n(1000), //nolint:gosec
 	)
 	if err != nil {
 		log.Println(err)
 	}
 
-	_, err = selectTx(ctx, tracer, db.Query(), path.Join(db.Name(), "/"),
+	_, err = selectTx(ctx, appSpans, db.Query(), path.Join(db.Name(), "/"),
 		rand.Int63n(25000), //nolint:gosec
 	)
 	if err != nil {
@@ -197,10 +198,7 @@ func upsertData(ctx context.Context, tracer trace.Tracer, c query.Client, prefix
 	return nil
 }
 
-func selectTx(ctx context.Context, tracer trace.Tracer, c query.Client, prefix string, limit int64) (count uint64, err error) {
-	ctx, span := tracer.Start(ctx, "selectTx")
-	defer span.End()
-
+func selectTx(ctx context.Context, appSpans *ydbOtel.SpanStarter, c query.Client, prefix string, limit int64) (count uint64, err error) {
 	q := fmt.Sprintf(`
 		SELECT
 			series_id,
@@ -210,6 +208,15 @@ func selectTx(ctx context.Context, tracer trace.Tracer, c query.Client, prefix s
 		"`"+path.Join(prefix, "series")+"`",
 		limit,
 	)
+
+	ctx, span := appSpans.StartQuery(ctx, "selectTx", q)
+	defer func() {
+		if err != nil {
+			span.Error(err)
+		}
+		span.End()
+	}()
+
 	err = c.DoTx(ctx,
 		func(ctx context.Context, tx query.TxActor) error {
 			count = 0
@@ -267,10 +274,7 @@ func selectTx(ctx context.Context, tracer trace.Tracer, c query.Client, prefix s
 	return count, err
 }
 
-func selectSession(ctx context.Context, tracer trace.Tracer, c query.Client, prefix string, limit int64) (count uint64, err error) {
-	ctx, span := tracer.Start(ctx, "selectSession")
-	defer span.End()
-
+func selectSession(ctx context.Context, appSpans *ydbOtel.SpanStarter, c query.Client, prefix string, limit int64) (count uint64, err error) {
 	q := fmt.Sprintf(`
 		SELECT
 			series_id,
@@ -280,6 +284,15 @@ func selectSession(ctx context.Context, tracer trace.Tracer, c query.Client, pre
 		"`"+path.Join(prefix, "series")+"`",
 		limit,
 	)
+
+	ctx, span := appSpans.StartQuery(ctx, "selectSession", q)
+	defer func() {
+		if err != nil {
+			span.Error(err)
+		}
+		span.End()
+	}()
+
 	err = c.Do(ctx,
 		func(ctx context.Context, s query.Session) error {
 			count = 0
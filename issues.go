@@ -0,0 +1,140 @@
+package ydb
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+)
+
+const (
+	issuesCodesAttribute     = "ydb.issues.codes"
+	issuesPathsAttribute     = "ydb.issues.paths"
+	issuesMessagesAttribute  = "ydb.issues.messages"
+	issuesPositionsAttribute = "ydb.issues.positions"
+	issuesSeverityAttribute  = "ydb.issues.severity"
+	issuesLogAttributeSuffix = ".issues"
+)
+
+// issuesError is implemented by ydb-go-sdk operation errors.
+type issuesError interface {
+	Issues() []*Ydb_Issue.IssueMessage
+}
+
+func issuesFromError(err error) []*Ydb_Issue.IssueMessage {
+	var withIssues issuesError
+	if err == nil || !errors.As(err, &withIssues) {
+		return nil
+	}
+
+	return withIssues.Issues()
+}
+
+// issuesToAttributes flattens issues tree of YDB error into span event attributes.
+// Slices are aligned by index, tree structure is kept in issue paths like "1.2".
+func issuesToAttributes(err error) []attribute.KeyValue {
+	issues := issuesFromError(err)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	var (
+		codes     []int64
+		paths     []string
+		messages  []string
+		positions []string
+	)
+
+	var walk func(prefix string, issues []*Ydb_Issue.IssueMessage)
+	walk = func(prefix string, issues []*Ydb_Issue.IssueMessage) {
+		for i, issue := range issues {
+			path := prefix + strconv.Itoa(i+1)
+			codes = append(codes, int64(issue.GetIssueCode()))
+			paths = append(paths, path)
+			messages = append(messages, issue.GetMessage())
+			positions = append(positions, issuePosition(issue.GetPosition()))
+			walk(path+".", issue.GetIssues())
+		}
+	}
+	walk("", issues)
+
+	return []attribute.KeyValue{
+		attribute.String(issuesSeverityAttribute, issueSeverityName(topSeverity(issues))),
+		attribute.Int64Slice(issuesCodesAttribute, codes),
+		attribute.StringSlice(issuesPathsAttribute, paths),
+		attribute.StringSlice(issuesMessagesAttribute, messages),
+		attribute.StringSlice(issuesPositionsAttribute, positions),
+	}
+}
+
+// issuesToLogAttribute returns issues tree of YDB error as structured log attribute.
+func issuesToLogAttribute(key string, err error) (otelLog.KeyValue, bool) {
+	issues := issuesFromError(err)
+	if len(issues) == 0 {
+		return otelLog.KeyValue{}, false
+	}
+
+	return otelLog.Slice(key+issuesLogAttributeSuffix, issuesToLogValues(issues)...), true
+}
+
+func issuesToLogValues(issues []*Ydb_Issue.IssueMessage) []otelLog.Value {
+	values := make([]otelLog.Value, len(issues))
+	for i, issue := range issues {
+		kvs := []otelLog.KeyValue{
+			otelLog.Int64("code", int64(issue.GetIssueCode())),
+			otelLog.String("severity", issueSeverityName(issue.GetSeverity())),
+			otelLog.String("message", issue.GetMessage()),
+		}
+		if position := issuePosition(issue.GetPosition()); position != "" {
+			kvs = append(kvs, otelLog.String("position", position))
+		}
+		if children := issue.GetIssues(); len(children) > 0 {
+			kvs = append(kvs, otelLog.Slice("issues", issuesToLogValues(children)...))
+		}
+		values[i] = otelLog.MapValue(kvs...)
+	}
+
+	return values
+}
+
+// topSeverity returns the most severe level of top-level issues.
+func topSeverity(issues []*Ydb_Issue.IssueMessage) uint32 {
+	severity := issues[0].GetSeverity()
+	for _, issue := range issues[1:] {
+		severity = min(severity, issue.GetSeverity())
+	}
+
+	return severity
+}
+
+// issueSeverityName returns name of YQL issue severity.
+func issueSeverityName(severity uint32) string {
+	switch severity {
+	case 0:
+		return "fatal"
+	case 1:
+		return "error"
+	case 2:
+		return "warning"
+	case 3:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// issuePosition formats position of issue in query text as [file:]row:column.
+func issuePosition(position *Ydb_Issue.IssueMessage_Position) string {
+	if position == nil {
+		return ""
+	}
+
+	s := strconv.FormatUint(uint64(position.GetRow()), 10) + ":" + strconv.FormatUint(uint64(position.GetColumn()), 10)
+	if file := position.GetFile(); file != "" {
+		return file + ":" + s
+	}
+
+	return s
+}
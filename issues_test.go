@@ -0,0 +1,89 @@
+package ydb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	otelLog "go.opentelemetry.io/otel/log"
+)
+
+type testIssuesError struct {
+	issues []*Ydb_Issue.IssueMessage
+}
+
+func (e *testIssuesError) Error() string {
+	return "scheme error"
+}
+
+func (e *testIssuesError) Issues() []*Ydb_Issue.IssueMessage {
+	return e.issues
+}
+
+func newTestIssuesError() error {
+	return fmt.Errorf("execute: %w", &testIssuesError{
+		issues: []*Ydb_Issue.IssueMessage{
+			{
+				Message:   "Type annotation",
+				IssueCode: 1030,
+				Severity:  1,
+				Issues: []*Ydb_Issue.IssueMessage{
+					{
+						Message:   "Cannot find table 'series'",
+						IssueCode: 2003,
+						Severity:  1,
+						Position:  &Ydb_Issue.IssueMessage_Position{Row: 3, Column: 10},
+					},
+				},
+			},
+			{
+				Message:  "Unused parameter",
+				Severity: 2,
+			},
+		},
+	})
+}
+
+func TestIssuesToAttributes(t *testing.T) {
+	attrs := attributesMap(issuesToAttributes(newTestIssuesError()))
+
+	require.Equal(t, "error", attrs[issuesSeverityAttribute].AsString())
+	require.Equal(t, []int64{1030, 2003, 0}, attrs[issuesCodesAttribute].AsInt64Slice())
+	require.Equal(t, []string{"1", "1.1", "2"}, attrs[issuesPathsAttribute].AsStringSlice())
+	require.Equal(t, []string{"", "3:10", ""}, attrs[issuesPositionsAttribute].AsStringSlice())
+}
+
+func TestIssuesToAttributesWithoutIssues(t *testing.T) {
+	require.Empty(t, issuesToAttributes(errors.New("plain")))
+	require.Empty(t, issuesToAttributes(nil))
+}
+
+func TestLogAdapterAddsIssues(t *testing.T) {
+	capture := &captureLogger{}
+	adapter := &logAdapter{logger: capture}
+
+	adapter.Log(context.Background(), "failed", log.Error(newTestIssuesError()))
+
+	require.Len(t, capture.records, 1)
+
+	var issues []otelLog.Value
+
+	capture.records[0].WalkAttributes(func(kv otelLog.KeyValue) bool {
+		if kv.Key == "error"+issuesLogAttributeSuffix {
+			issues = kv.Value.AsSlice()
+		}
+
+		return true
+	})
+	require.Len(t, issues, 2)
+
+	first := issues[0].AsMap()
+	require.Equal(t, "code", first[0].Key)
+	require.Equal(t, int64(1030), first[0].Value.AsInt64())
+	require.Equal(t, "issues", first[len(first)-1].Key)
+	require.Len(t, first[len(first)-1].Value.AsSlice(), 1)
+}
@@ -0,0 +1,179 @@
+package ydb
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	spanAbandonedAttribute = "ydb.span.abandoned"
+	openSpansMetricName    = "ydb.spans.open"
+	leakedSpanLogMessage   = "ydb span is open longer than limit"
+)
+
+// openSpan is a span tracked by leak detector.
+type openSpan struct {
+	// owner is an adapter which started span, spans are abandoned by close of driver of owner
+	owner    *adapter
+	span     otelTrace.Span
+	name     string
+	started  time.Time
+	reported bool
+}
+
+// leakDetector tracks open spans of adapter, reports spans which are open longer
+// than limit and ends dangling spans on driver close.
+type leakDetector struct {
+	limit  time.Duration
+	logger otelLog.Logger
+	gauge  metric.Int64ObservableGauge
+
+	mu        sync.Mutex
+	open      map[*spanState]*openSpan
+	lastCheck time.Time
+}
+
+func newLeakDetector(limit time.Duration, logger otelLog.Logger, meter metric.Meter) *leakDetector {
+	d := &leakDetector{
+		limit:     limit,
+		logger:    loggerFrom(logger),
+		open:      make(map[*spanState]*openSpan),
+		lastCheck: time.Now(),
+	}
+
+	meter = meterFrom(meter)
+
+	var err error
+	d.gauge, err = meter.Int64ObservableGauge(openSpansMetricName,
+		metric.WithDescription("Number of open ydb-go-sdk spans by operation"),
+		metric.WithUnit("{span}"),
+	)
+	if err == nil {
+		_, err = meter.RegisterCallback(d.observe, d.gauge)
+	}
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return d
+}
+
+func (d *leakDetector) track(owner *adapter, state *spanState, name string) {
+	now := time.Now()
+
+	d.mu.Lock()
+	d.open[state] = &openSpan{
+		owner:   owner,
+		span:    state.owner,
+		name:    name,
+		started: now,
+	}
+	check := now.Sub(d.lastCheck) >= d.limit
+	if check {
+		d.lastCheck = now
+	}
+	d.mu.Unlock()
+
+	if check {
+		d.reportLeaks(now)
+	}
+}
+
+func (d *leakDetector) untrack(state *spanState) {
+	d.mu.Lock()
+	delete(d.open, state)
+	d.mu.Unlock()
+}
+
+// reportLeaks emits log record for each span which became open longer than limit.
+func (d *leakDetector) reportLeaks(now time.Time) {
+	var leaked []openSpan
+
+	d.mu.Lock()
+	for _, s := range d.open {
+		if !s.reported && now.Sub(s.started) > d.limit {
+			s.reported = true
+			leaked = append(leaked, *s)
+		}
+	}
+	d.mu.Unlock()
+
+	for i := range leaked {
+		spanCtx := leaked[i].span.SpanContext()
+
+		record := otelLog.Record{}
+		record.SetTimestamp(now)
+		record.SetObservedTimestamp(now)
+		record.SetSeverity(otelLog.SeverityWarn)
+		record.SetSeverityText("WARN")
+		record.SetBody(otelLog.StringValue(leakedSpanLogMessage))
+		record.AddAttributes(
+			otelLog.String("operation", leaked[i].name),
+			otelLog.Int64("age_ms", now.Sub(leaked[i].started).Milliseconds()),
+			otelLog.String(traceIDLogField, spanCtx.TraceID().String()),
+			otelLog.String(spanIDLogField, spanCtx.SpanID().String()),
+		)
+
+		d.logger.Emit(otelTrace.ContextWithSpanContext(context.Background(), spanCtx), record)
+	}
+}
+
+// observe reports number of open spans by operation and checks for leaks.
+func (d *leakDetector) observe(_ context.Context, observer metric.Observer) error {
+	d.mu.Lock()
+	counts := make(map[string]int64, len(d.open))
+	for _, s := range d.open {
+		counts[s.name]++
+	}
+	d.mu.Unlock()
+
+	d.reportLeaks(time.Now())
+
+	for name, count := range counts {
+		observer.ObserveInt64(d.gauge, count, metric.WithAttributes(attribute.String("operation", name)))
+	}
+
+	return nil
+}
+
+// abandon ends spans of owners started before given time with ydb.span.abandoned attribute.
+func (d *leakDetector) abandon(before time.Time, owners []*adapter) {
+	var abandoned []otelTrace.Span
+
+	d.mu.Lock()
+	for state, s := range d.open {
+		if s.started.Before(before) && slices.Contains(owners, s.owner) {
+			abandoned = append(abandoned, s.span)
+			delete(d.open, state)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, s := range abandoned {
+		s.SetAttributes(attribute.Bool(spanAbandonedAttribute, true))
+		s.End()
+	}
+}
+
+// driverTrace returns driver trace which ends dangling spans of adapters of driver on driver close.
+// Spans of other drivers sharing detector are kept.
+func (d *leakDetector) driverTrace(owners ...*adapter) trace.Driver {
+	return trace.Driver{
+		OnClose: func(trace.DriverCloseStartInfo) func(trace.DriverCloseDoneInfo) {
+			closeStarted := time.Now()
+
+			return func(trace.DriverCloseDoneInfo) {
+				d.abandon(closeStarted, owners)
+			}
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestLeakDetectorReportsAndAbandonsSpans(t *testing.T) {
+	capture := &captureLogger{}
+	a, recorder := newTestSpansAdapter(
+		WithLeakDetector(time.Millisecond, capture, noop.NewMeterProvider().Meter("test")),
+	)
+
+	_, leaked := a.Start(context.Background(), "topic.Reader.Read")
+	_, ended := a.Start(context.Background(), "query.Exec")
+	ended.End()
+
+	time.Sleep(2 * time.Millisecond)
+	a.leaks.reportLeaks(time.Now())
+	a.leaks.reportLeaks(time.Now())
+
+	require.Len(t, capture.records, 1)
+	require.Equal(t, leakedSpanLogMessage, capture.records[0].Body().AsString())
+
+	a.leaks.driverTrace(a).OnClose(trace.DriverCloseStartInfo{})(trace.DriverCloseDoneInfo{})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "topic.Reader.Read", spans[1].Name())
+	require.True(t, spanAttributes(spans[1])[spanAbandonedAttribute].AsBool())
+
+	leaked.End()
+	require.Empty(t, a.leaks.open)
+}
+
+func TestLeakDetectorSharedByAdapters(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	opt := WithLeakDetector(time.Hour, &captureLogger{}, provider.Meter("test"))
+
+	first := newAdapter(nil, opt)
+	second := newAdapter(nil, opt)
+	require.Same(t, first.leaks, second.leaks)
+
+	_, s1 := first.Start(context.Background(), "query.Exec")
+	_, s2 := second.Start(context.Background(), "query.Exec")
+	_, s3 := second.Start(context.Background(), "topic.Reader.Read")
+	defer s1.End()
+	defer s2.End()
+	defer s3.End()
+
+	gauge, ok := collectMetrics(t, reader)[openSpansMetricName].Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+
+	counts := map[string]int64{}
+	for _, dp := range gauge.DataPoints {
+		operation, _ := dp.Attributes.Value("operation")
+		require.NotContains(t, counts, operation.AsString())
+		counts[operation.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]int64{"query.Exec": 2, "topic.Reader.Read": 1}, counts)
+}
+
+func TestLeakDetectorAbandonsSpansOfClosedDriverOnly(t *testing.T) {
+	opt := WithLeakDetector(time.Hour, &captureLogger{}, noop.NewMeterProvider().Meter("test"))
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	orders := newAdapter(tracer, opt)
+	billing := newAdapter(tracer, opt)
+
+	_, ordersSpan := orders.Start(context.Background(), "query.Exec")
+	_, billingSpan := billing.Start(context.Background(), "query.Exec")
+
+	orders.leaks.driverTrace(orders).OnClose(trace.DriverCloseStartInfo{})(trace.DriverCloseDoneInfo{})
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.True(t, spanAttributes(ended[0])[spanAbandonedAttribute].AsBool())
+	require.Len(t, billing.leaks.open, 1)
+
+	billingSpan.End()
+	ordersSpan.End()
+	require.Len(t, recorder.Ended(), 2)
+	require.Empty(t, billing.leaks.open)
+}
@@ -9,6 +9,7 @@ import (
 	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
 	"github.com/ydb-platform/ydb-go-sdk/v3/log"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
 	otelLog "go.opentelemetry.io/otel/log"
 	otelTrace "go.opentelemetry.io/otel/trace"
 )
@@ -16,13 +17,15 @@ import (
 var _ log.Logger = (*logAdapter)(nil)
 
 type logAdapter struct {
-	logger otelLog.Logger
+	logger   otelLog.Logger
+	identity *driverIdentity
 }
 
 type loggerConfig struct {
 	logger   otelLog.Logger
 	detailer trace.Detailer
 	logOpts  []log.Option
+	identity *driverIdentity
 }
 
 func loggerConfigFrom(logger otelLog.Logger, opts ...loggerOption) *loggerConfig {
@@ -42,10 +45,19 @@ func loggerConfigFrom(logger otelLog.Logger, opts ...loggerOption) *loggerConfig
 func WithLogger(logger otelLog.Logger, opts ...loggerOption) ydb.Option {
 	cfg := loggerConfigFrom(logger, opts...)
 
-	return ydb.WithLogger(&logAdapter{logger: cfg.logger}, cfg.detailer, cfg.logOpts...)
+	opt := ydb.WithLogger(&logAdapter{logger: cfg.logger, identity: cfg.identity}, cfg.detailer, cfg.logOpts...)
+	if cfg.identity == nil {
+		return opt
+	}
+
+	return ydb.MergeOptions(opt, ydb.WithTraceDriver(cfg.identity.driverTrace()))
 }
 
 func (a *logAdapter) Log(ctx context.Context, msg string, fields ...log.Field) {
+	if !enabledInContext(ctx, logNamesDetails(log.NamesFromContext(ctx))) {
+		return
+	}
+
 	severity, severityText := severityFromLevel(log.LevelFromContext(ctx))
 
 	record := otelLog.Record{}
@@ -70,6 +82,12 @@ func (a *logAdapter) Log(ctx context.Context, msg string, fields ...log.Field) {
 	}
 
 	attrs = append(attrs, fieldsToLogAttributes(contextFields)...)
+	for _, attr := range attributesFromContext(ctx) {
+		attrs = append(attrs, attributeToLogAttribute(attr))
+	}
+	for _, attr := range a.identity.attributes() {
+		attrs = append(attrs, attributeToLogAttribute(attr))
+	}
 	record.AddAttributes(attrs...)
 
 	a.logger.Emit(ctx, record)
@@ -110,9 +128,15 @@ func severityFromLevel(level log.Level) (otelLog.Severity, string) {
 }
 
 func fieldsToLogAttributes(fields []log.Field) []otelLog.KeyValue {
-	attrs := make([]otelLog.KeyValue, len(fields))
-	for i, field := range fields {
-		attrs[i] = fieldToLogAttribute(field)
+	attrs := make([]otelLog.KeyValue, 0, len(fields))
+	for _, field := range fields {
+		attrs = append(attrs, fieldToLogAttribute(field))
+		if field.Type() != log.ErrorType {
+			continue
+		}
+		if issues, ok := issuesToLogAttribute(field.Key(), field.ErrorValue()); ok {
+			attrs = append(attrs, issues)
+		}
 	}
 
 	return attrs
@@ -154,3 +178,37 @@ func stringSliceValues(values []string) []otelLog.Value {
 
 	return result
 }
+
+func attributeToLogAttribute(attr attribute.KeyValue) otelLog.KeyValue {
+	key := string(attr.Key)
+
+	switch attr.Value.Type() {
+	case attribute.BOOL:
+		return otelLog.Bool(key, attr.Value.AsBool())
+	case attribute.INT64:
+		return otelLog.Int64(key, attr.Value.AsInt64())
+	case attribute.FLOAT64:
+		return otelLog.Float64(key, attr.Value.AsFloat64())
+	case attribute.STRING:
+		return otelLog.String(key, attr.Value.AsString())
+	case attribute.STRINGSLICE:
+		return otelLog.Slice(key, stringSliceValues(attr.Value.AsStringSlice())...)
+	case attribute.BOOLSLICE:
+		return otelLog.Slice(key, sliceValues(attr.Value.AsBoolSlice(), otelLog.BoolValue)...)
+	case attribute.INT64SLICE:
+		return otelLog.Slice(key, sliceValues(attr.Value.AsInt64Slice(), otelLog.Int64Value)...)
+	case attribute.FLOAT64SLICE:
+		return otelLog.Slice(key, sliceValues(attr.Value.AsFloat64Slice(), otelLog.Float64Value)...)
+	default:
+		return otelLog.String(key, attr.Value.Emit())
+	}
+}
+
+func sliceValues[T any](values []T, value func(T) otelLog.Value) []otelLog.Value {
+	result := make([]otelLog.Value, len(values))
+	for i, v := range values {
+		result[i] = value(v)
+	}
+
+	return result
+}
@@ -11,8 +11,10 @@ import (
 	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
 	"github.com/ydb-platform/ydb-go-sdk/v3/metrics"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 const defaultMetricsSeparator = "_"
@@ -30,51 +32,109 @@ type metricsConfig struct {
 	namespace    string
 	separator    string
 	timerBuckets []float64
-
-	m          sync.Mutex
-	counters   map[metricInstrumentKey]metrics.CounterVec
-	gauges     map[metricInstrumentKey]metrics.GaugeVec
-	timers     map[metricInstrumentKey]metrics.TimerVec
-	histograms map[metricInstrumentKey]metrics.HistogramVec
+	timerUnit    TimerUnit
+	identity     *driverIdentity
+	maxLabelSets int
+	cardinality  *cardinalityLimit
+	descriptions metricDescriptions
+	errorHandler func(error)
+	views        []metricView
+	semconv      bool
+	// queryOperations enables latency of queries labeled by operation and collection derived from YQL
+	queryOperations bool
+	// system is a dotted path of subsystems used for lookup of metric descriptions
+	system string
+
+	// registry is shared by config and all its WithSystem children
+	registry *metricsRegistry
+}
+
+// metricsRegistry caches instruments by full instrument name, so repeated WithSystem calls
+// for the same subsystem reuse instruments and gauge values.
+type metricsRegistry struct {
+	mu         sync.Mutex
+	counters   map[metricInstrumentKey]*counterVec
+	gauges     map[metricInstrumentKey]*gaugeVec
+	timers     map[metricInstrumentKey]*timerVec
+	histograms map[metricInstrumentKey]*histogramVec
+}
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		counters:   map[metricInstrumentKey]*counterVec{},
+		gauges:     map[metricInstrumentKey]*gaugeVec{},
+		timers:     map[metricInstrumentKey]*timerVec{},
+		histograms: map[metricInstrumentKey]*histogramVec{},
+	}
 }
 
 type metricInstrumentKey struct {
 	name       string
 	buckets    string
 	labelNames string
+	attrs      attribute.Distinct
 }
 
-func newMetricInstrumentKey(name, buckets string, labelNames []string) metricInstrumentKey {
+func newMetricInstrumentKey(name, buckets string, labelNames []string, attrs []attribute.KeyValue) metricInstrumentKey {
+	set := attribute.NewSet(attrs...)
+
 	return metricInstrumentKey{
 		name:       name,
 		buckets:    buckets,
 		labelNames: strings.Join(labelNames, "\xff"),
+		attrs:      set.Equivalent(),
 	}
 }
 
 // metricsConfigFromOpts returns metrics registry config for OpenTelemetry instruments.
 func metricsConfigFromOpts(meter metric.Meter, opts ...metricsOption) metrics.Config {
 	cfg := &metricsConfig{
-		meter:        meterFrom(meter),
-		detailer:     trace.DetailsAll,
-		separator:    defaultMetricsSeparator,
-		timerBuckets: defaultTimerBuckets,
-		counters:     map[metricInstrumentKey]metrics.CounterVec{},
-		gauges:       map[metricInstrumentKey]metrics.GaugeVec{},
-		timers:       map[metricInstrumentKey]metrics.TimerVec{},
-		histograms:   map[metricInstrumentKey]metrics.HistogramVec{},
+		meter:     meterFrom(meter),
+		detailer:  trace.DetailsAll,
+		separator: defaultMetricsSeparator,
+		timerUnit: TimerUnitSeconds,
+		registry:  newMetricsRegistry(),
 	}
 	for _, opt := range opts {
 		opt.applyMetricsOption(cfg)
 	}
 
+	if cfg.timerBuckets == nil {
+		cfg.timerBuckets = cfg.timerUnit.buckets(defaultTimerBuckets)
+	}
+
+	if cfg.maxLabelSets > 0 {
+		cfg.cardinality = newCardinalityLimit(cfg.meter, cfg.maxLabelSets, cfg.handleError)
+	}
+
 	return cfg
 }
 
 // WithMetrics enables ydb-go-sdk metrics export via OpenTelemetry.
 // If meter is nil, otel.Meter("ydb-go-sdk") is used.
 func WithMetrics(meter metric.Meter, opts ...metricsOption) ydb.Option {
-	return metrics.WithTraces(metricsConfigFromOpts(meter, opts...))
+	cfg, _ := metricsConfigFromOpts(meter, opts...).(*metricsConfig)
+	if cfg.identity == nil && !cfg.queryOperations && !cfg.semconv {
+		return metrics.WithTraces(cfg)
+	}
+
+	var options []ydb.Option
+	if cfg.identity != nil {
+		options = append(options, ydb.WithTraceDriver(cfg.identity.driverTrace()))
+	}
+
+	options = append(options, metrics.WithTraces(cfg))
+
+	if cfg.queryOperations {
+		options = append(options, ydb.WithTraceQuery(newQueryOperations(cfg).queryTrace()))
+	}
+
+	if cfg.semconv {
+		pools := newPoolMetrics(cfg)
+		options = append(options, ydb.WithTraceQuery(pools.queryTrace()), ydb.WithTraceTable(pools.tableTrace()))
+	}
+
+	return ydb.MergeOptions(options...)
 }
 
 func (c *metricsConfig) Details() trace.Details {
@@ -82,136 +142,228 @@ func (c *metricsConfig) Details() trace.Details {
 }
 
 func (c *metricsConfig) WithSystem(subsystem string) metrics.Config {
-	return &metricsConfig{
-		meter:        c.meter,
-		detailer:     c.detailer,
-		namespace:    c.join(c.namespace, subsystem),
-		separator:    c.separator,
-		timerBuckets: c.timerBuckets,
-		counters:     map[metricInstrumentKey]metrics.CounterVec{},
-		gauges:       map[metricInstrumentKey]metrics.GaugeVec{},
-		timers:       map[metricInstrumentKey]metrics.TimerVec{},
-		histograms:   map[metricInstrumentKey]metrics.HistogramVec{},
-	}
+	child := *c
+	child.namespace = c.join(c.namespace, subsystem)
+	child.system = joinMetricPath(c.system, subsystem)
+
+	return &child
 }
 
 func (c *metricsConfig) CounterVec(name string, labelNames ...string) metrics.CounterVec {
-	instrumentName := c.instrumentName(name)
-	key := newMetricInstrumentKey(instrumentName, "", labelNames)
+	inst := c.instrument(name, "counter")
+	view := resolveMetricViews(c.views, inst.name)
+	if view.drop {
+		return &counterVec{counter: noop.Int64Counter{}}
+	}
 
-	c.m.Lock()
-	defer c.m.Unlock()
+	instrumentName := view.name
+	labelNames = view.labelNames(labelNames)
+	key := newMetricInstrumentKey(instrumentName, "", labelNames, inst.attrs)
 
-	if cnt, ok := c.counters[key]; ok {
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
+
+	if cnt, ok := c.registry.counters[key]; ok {
 		return cnt
 	}
 
+	description := inst.description
 	counter, err := c.meter.Int64Counter(
 		instrumentName,
-		metric.WithDescription("ydb-go-sdk counter"),
+		metric.WithDescription(description.Description),
+		metric.WithUnit(description.Unit),
 	)
 	if err != nil {
-		panic(err)
+		c.handleError(err)
+		counter = noop.Int64Counter{}
 	}
 
 	cnt := &counterVec{
 		counter:    counter,
 		labelNames: labelNames,
+		identity:   c.identity,
+		allowed:    view.allowed,
+		constAttrs: inst.attrs,
+		limiter:    c.cardinality.limiter(instrumentName),
 	}
-	c.counters[key] = cnt
+	c.registry.counters[key] = cnt
 
 	return cnt
 }
 
 func (c *metricsConfig) GaugeVec(name string, labelNames ...string) metrics.GaugeVec {
-	instrumentName := c.instrumentName(name)
-	key := newMetricInstrumentKey(instrumentName, "", labelNames)
+	inst := c.instrument(name, "gauge")
+	view := resolveMetricViews(c.views, inst.name)
+	if view.drop {
+		return &gaugeVec{gauge: noop.Float64ObservableGauge{}}
+	}
+
+	instrumentName := view.name
+	labelNames = view.labelNames(labelNames)
+	key := newMetricInstrumentKey(instrumentName, "", labelNames, inst.attrs)
 
-	c.m.Lock()
-	defer c.m.Unlock()
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
 
-	if g, ok := c.gauges[key]; ok {
+	if g, ok := c.registry.gauges[key]; ok {
 		return g
 	}
 
-	upDown, err := c.meter.Float64UpDownCounter(
+	description := inst.description
+	gauge, err := c.meter.Float64ObservableGauge(
 		instrumentName,
-		metric.WithDescription("ydb-go-sdk gauge"),
+		metric.WithDescription(description.Description),
+		metric.WithUnit(description.Unit),
 	)
 	if err != nil {
-		panic(err)
+		c.handleError(err)
+		gauge = noop.Float64ObservableGauge{}
 	}
 
 	g := &gaugeVec{
-		upDown:     upDown,
+		gauge:      gauge,
 		labelNames: labelNames,
+		identity:   c.identity,
+		allowed:    view.allowed,
+		constAttrs: inst.attrs,
+		limiter:    c.cardinality.limiter(instrumentName),
 	}
-	c.gauges[key] = g
+	if _, err = c.meter.RegisterCallback(g.observe, gauge); err != nil {
+		c.handleError(err)
+	}
+	c.registry.gauges[key] = g
 
 	return g
 }
 
 func (c *metricsConfig) TimerVec(name string, labelNames ...string) metrics.TimerVec {
-	instrumentName := c.instrumentName(name)
-	key := newMetricInstrumentKey(instrumentName, fmt.Sprintf("%v", c.timerBuckets), labelNames)
+	inst := c.instrument(name, "timer")
+	view := resolveMetricViews(c.views, inst.name)
+	unit, buckets := c.timerUnitOf(inst)
+	if view.drop {
+		return &timerVec{histogram: noop.Float64Histogram{}, unit: unit}
+	}
 
-	c.m.Lock()
-	defer c.m.Unlock()
+	instrumentName := view.name
+	labelNames = view.labelNames(labelNames)
+	key := newMetricInstrumentKey(instrumentName, fmt.Sprintf("%v", buckets), labelNames, inst.attrs)
 
-	if t, ok := c.timers[key]; ok {
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
+
+	if t, ok := c.registry.timers[key]; ok {
 		return t
 	}
 
 	histogram, err := c.meter.Float64Histogram(
 		instrumentName,
-		metric.WithDescription("ydb-go-sdk timer"),
-		metric.WithUnit("s"),
-		metric.WithExplicitBucketBoundaries(c.timerBuckets...),
+		metric.WithDescription(inst.description.Description),
+		metric.WithUnit(string(unit)),
+		metric.WithExplicitBucketBoundaries(buckets...),
 	)
 	if err != nil {
-		panic(err)
+		c.handleError(err)
+		histogram = noop.Float64Histogram{}
 	}
 
 	t := &timerVec{
 		histogram:  histogram,
+		unit:       unit,
 		labelNames: labelNames,
+		identity:   c.identity,
+		allowed:    view.allowed,
+		constAttrs: inst.attrs,
+		limiter:    c.cardinality.limiter(instrumentName),
 	}
-	c.timers[key] = t
+	c.registry.timers[key] = t
 
 	return t
 }
 
 func (c *metricsConfig) HistogramVec(name string, buckets []float64, labelNames ...string) metrics.HistogramVec {
-	instrumentName := c.instrumentName(name)
+	inst := c.instrument(name, "histogram")
+	view := resolveMetricViews(c.views, inst.name)
+	if view.drop {
+		return &histogramVec{histogram: noop.Float64Histogram{}}
+	}
+
+	instrumentName := view.name
+	labelNames = view.labelNames(labelNames)
 
 	histogramBuckets := append([]float64(nil), buckets...)
-	key := newMetricInstrumentKey(instrumentName, fmt.Sprintf("%v", histogramBuckets), labelNames)
+	key := newMetricInstrumentKey(instrumentName, fmt.Sprintf("%v", histogramBuckets), labelNames, inst.attrs)
 
-	c.m.Lock()
-	defer c.m.Unlock()
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
 
-	if h, ok := c.histograms[key]; ok {
+	if h, ok := c.registry.histograms[key]; ok {
 		return h
 	}
 
+	description := inst.description
 	histogram, err := c.meter.Float64Histogram(
 		instrumentName,
-		metric.WithDescription("ydb-go-sdk histogram"),
+		metric.WithDescription(description.Description),
+		metric.WithUnit(description.Unit),
 		metric.WithExplicitBucketBoundaries(histogramBuckets...),
 	)
 	if err != nil {
-		panic(err)
+		c.handleError(err)
+		histogram = noop.Float64Histogram{}
 	}
 
 	h := &histogramVec{
 		histogram:  histogram,
 		labelNames: labelNames,
+		identity:   c.identity,
+		allowed:    view.allowed,
+		constAttrs: inst.attrs,
+		limiter:    c.cardinality.limiter(instrumentName),
 	}
-	c.histograms[key] = h
+	c.registry.histograms[key] = h
 
 	return h
 }
 
+// timerUnitOf returns unit and buckets of timer instrument. Instruments of semantic conventions are
+// always in seconds with timer buckets scaled to seconds, other timers use unit of WithTimerUnit.
+func (c *metricsConfig) timerUnitOf(inst instrument) (TimerUnit, []float64) {
+	if !inst.semconv || c.timerUnit == TimerUnitSeconds {
+		return c.timerUnit, c.timerBuckets
+	}
+
+	return TimerUnitSeconds, c.timerUnit.rescale(c.timerBuckets, TimerUnitSeconds)
+}
+
+// instrument returns instrument of metric with name of config subsystem.
+func (c *metricsConfig) instrument(name, kind string) instrument {
+	if c.semconv {
+		if i, ok := semconvInstrument(c.system, name, c.queryOperations); ok {
+			if description, ok := c.descriptions.override(joinMetricPath(c.system, name), i.name); ok {
+				i.description = description
+			}
+
+			return i
+		}
+	}
+
+	return instrument{
+		name:        c.instrumentName(name),
+		description: c.descriptions.describe(c.system, name, kind),
+	}
+}
+
+// handleError reports error of instrument creation to error handler, otel.Handle by default.
+func (c *metricsConfig) handleError(err error) {
+	if c.errorHandler != nil {
+		c.errorHandler(err)
+
+		return
+	}
+
+	otel.Handle(err)
+}
+
 func (c *metricsConfig) join(a, b string) string {
 	if a == "" {
 		return b
@@ -231,35 +383,58 @@ func (c *metricsConfig) instrumentName(name string) string {
 type counterVec struct {
 	counter    metric.Int64Counter
 	labelNames []string
+	identity   *driverIdentity
+	limiter    *cardinalityLimiter
+	allowed    map[string]struct{}
+	// constAttrs are attributes of instrument added to all measurements
+	constAttrs []attribute.KeyValue
+
+	series seriesCache
 }
 
 func (c *counterVec) With(labels map[string]string) metrics.Counter {
+	labels = filterLabels(labels, c.allowed)
+	constAttrs := c.constAttrs
+	if kind := contextErrorKindFromStatus(labels["status"]); kind != notContextError {
+		constAttrs = append(kind.attributes(), c.constAttrs...)
+	}
+
 	return &counterMetric{
 		counter: c.counter,
-		attrs:   labelsToAttributes(labels, c.labelNames),
+		options: seriesOptionsFor(&c.series, c.limiter, labels, c.labelNames, constAttrs, c.identity),
 	}
 }
 
 type counterMetric struct {
 	counter metric.Int64Counter
-	attrs   []attribute.KeyValue
+	options *seriesOptions
 }
 
 func (c *counterMetric) Inc() {
-	c.counter.Add(context.Background(), 1, metric.WithAttributes(c.attrs...))
+	c.counter.Add(context.Background(), 1, c.options.add...)
 }
 
 type gaugeVec struct {
-	upDown     metric.Float64UpDownCounter
+	gauge      metric.Float64ObservableGauge
 	labelNames []string
-
-	mu      sync.Mutex
+	identity   *driverIdentity
+	limiter    *cardinalityLimiter
+	allowed    map[string]struct{}
+	// constAttrs are attributes of instrument added to all measurements
+	constAttrs []attribute.KeyValue
+
+	mu sync.Mutex
+	// metrics are keyed by labels only, driver identity is attached at collection time, so series
+	// created before driver init keep their values once identity is known.
 	metrics map[string]*gaugeMetric
 }
 
 func (g *gaugeVec) With(labels map[string]string) metrics.Gauge {
-	attrs := labelsToAttributes(labels, g.labelNames)
+	labels = filterLabels(labels, g.allowed)
 	key := labelsCacheKey(labels, g.labelNames)
+	if g.limiter != nil && !g.limiter.allow(key) {
+		key = overflowLabelsCacheKey
+	}
 
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -268,100 +443,145 @@ func (g *gaugeVec) With(labels map[string]string) metrics.Gauge {
 		g.metrics = make(map[string]*gaugeMetric)
 	}
 
-	if metric, ok := g.metrics[key]; ok {
-		return metric
+	if m, ok := g.metrics[key]; ok {
+		return m
+	}
+
+	attrs := append(labelsToAttributes(labels, g.labelNames), g.constAttrs...)
+	if key == overflowLabelsCacheKey {
+		attrs = overflowAttributes(g.constAttrs, nil)
+	}
+
+	m := &gaugeMetric{
+		attrs: attrs,
 	}
+	g.metrics[key] = m
+
+	return m
+}
+
+// observe reports last values of all gauge series with current driver identity.
+func (g *gaugeVec) observe(_ context.Context, observer metric.Observer) error {
+	identity := g.identity.snapshot()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-	metric := &gaugeMetric{
-		upDown: g.upDown,
-		attrs:  attrs,
+	for _, m := range g.metrics {
+		observer.ObserveFloat64(g.gauge, m.value(), m.options(identity))
 	}
-	g.metrics[key] = metric
 
-	return metric
+	return nil
 }
 
 type gaugeMetric struct {
-	mu     sync.Mutex
-	last   float64
-	hasVal bool
+	mu  sync.Mutex
+	val float64
 
-	upDown metric.Float64UpDownCounter
-	attrs  []attribute.KeyValue
+	// attrs are attributes of series without driver identity
+	attrs []attribute.KeyValue
+	// identity and option are measurement option of series with identity attributes of last collection
+	identity *[]attribute.KeyValue
+	option   metric.MeasurementOption
 }
 
 func (g *gaugeMetric) Add(delta float64) {
 	g.mu.Lock()
-	g.last += delta
-	g.hasVal = true
+	g.val += delta
 	g.mu.Unlock()
-
-	if delta == 0 {
-		return
-	}
-
-	g.upDown.Add(context.Background(), delta, metric.WithAttributes(g.attrs...))
 }
 
 func (g *gaugeMetric) Set(value float64) {
 	g.mu.Lock()
+	g.val = value
+	g.mu.Unlock()
+}
 
-	delta := value
-	if g.hasVal {
-		delta = value - g.last
+// options returns measurement option of series with identity, it is rebuilt only when identity changes.
+// Callers must hold mutex of gaugeVec.
+func (g *gaugeMetric) options(identity *[]attribute.KeyValue) metric.MeasurementOption {
+	if g.option == nil || g.identity != identity {
+		attrs := g.attrs
+		if identity != nil {
+			attrs = append(slices.Clip(attrs), *identity...)
+		}
+		g.identity = identity
+		g.option = metric.WithAttributes(attrs...)
 	}
 
-	g.last = value
-	g.hasVal = true
-	g.mu.Unlock()
+	return g.option
+}
 
-	if delta == 0 {
-		return
-	}
+func (g *gaugeMetric) value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-	g.upDown.Add(context.Background(), delta, metric.WithAttributes(g.attrs...))
+	return g.val
 }
 
 type timerVec struct {
 	histogram  metric.Float64Histogram
+	unit       TimerUnit
 	labelNames []string
+	identity   *driverIdentity
+	limiter    *cardinalityLimiter
+	allowed    map[string]struct{}
+	// constAttrs are attributes of instrument added to all measurements
+	constAttrs []attribute.KeyValue
+
+	series seriesCache
 }
 
 func (t *timerVec) With(labels map[string]string) metrics.Timer {
+	labels = filterLabels(labels, t.allowed)
+
 	return &timerMetric{
 		histogram: t.histogram,
-		attrs:     labelsToAttributes(labels, t.labelNames),
+		unit:      t.unit,
+		options: seriesOptionsFor(&t.series, t.limiter, labels, t.labelNames, t.constAttrs,
+			t.identity),
 	}
 }
 
 type timerMetric struct {
 	histogram metric.Float64Histogram
-	attrs     []attribute.KeyValue
+	unit      TimerUnit
+	options   *seriesOptions
 }
 
 func (t *timerMetric) Record(value time.Duration) {
-	t.histogram.Record(context.Background(), value.Seconds(), metric.WithAttributes(t.attrs...))
+	t.histogram.Record(context.Background(), t.unit.value(value), t.options.record...)
 }
 
 type histogramVec struct {
 	histogram  metric.Float64Histogram
 	labelNames []string
+	identity   *driverIdentity
+	limiter    *cardinalityLimiter
+	allowed    map[string]struct{}
+	// constAttrs are attributes of instrument added to all measurements
+	constAttrs []attribute.KeyValue
+
+	series seriesCache
 }
 
 func (h *histogramVec) With(labels map[string]string) metrics.Histogram {
+	labels = filterLabels(labels, h.allowed)
+
 	return &histogramMetric{
 		histogram: h.histogram,
-		attrs:     labelsToAttributes(labels, h.labelNames),
+		options: seriesOptionsFor(&h.series, h.limiter, labels, h.labelNames, h.constAttrs,
+			h.identity),
 	}
 }
 
 type histogramMetric struct {
 	histogram metric.Float64Histogram
-	attrs     []attribute.KeyValue
+	options   *seriesOptions
 }
 
 func (h *histogramMetric) Record(value float64) {
-	h.histogram.Record(context.Background(), value, metric.WithAttributes(h.attrs...))
+	h.histogram.Record(context.Background(), value, h.options.record...)
 }
 
 func labelsToAttributes(labels map[string]string, labelNames []string) []attribute.KeyValue {
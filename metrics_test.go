@@ -1,12 +1,45 @@
 package ydb
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
+func newTestMeterProvider() (*sdkMetric.MeterProvider, *sdkMetric.ManualReader) {
+	reader := sdkMetric.NewManualReader()
+
+	return sdkMetric.NewMeterProvider(sdkMetric.WithReader(reader)), reader
+}
+
+// collectMetrics returns collected metrics by name.
+func collectMetrics(t *testing.T, reader *sdkMetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	result := map[string]metricdata.Metrics{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			result[m.Name] = m
+		}
+	}
+
+	return result
+}
+
 func TestCounterVecDifferentLabelNamesNotCachedTogether(t *testing.T) {
 	cfg, ok := metricsConfigFromOpts(noop.NewMeterProvider().Meter("test")).(*metricsConfig)
 	require.True(t, ok)
@@ -48,3 +81,558 @@ func TestLabelsCacheKeyIncludesExtraLabels(t *testing.T) {
 
 	require.NotEqual(t, keyA, keyB)
 }
+
+func TestGaugeVecReportsAbsoluteValues(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"))
+
+	vec := cfg.GaugeVec("pool_size", "node_id")
+	vec.With(map[string]string{"node_id": "1"}).Set(10)
+	vec.With(map[string]string{"node_id": "1"}).Add(-3)
+	vec.With(map[string]string{"node_id": "2"}).Add(2)
+	vec.With(map[string]string{"node_id": "2"}).Add(2)
+
+	gauge, ok := collectMetrics(t, reader)["pool_size"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+
+	values := map[string]float64{}
+	for _, dp := range gauge.DataPoints {
+		nodeID, _ := dp.Attributes.Value("node_id")
+		values[nodeID.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]float64{"1": 7, "2": 4}, values)
+
+	vec.With(map[string]string{"node_id": "1"}).Set(5)
+
+	gauge, ok = collectMetrics(t, reader)["pool_size"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 2)
+	for _, dp := range gauge.DataPoints {
+		if nodeID, _ := dp.Attributes.Value("node_id"); nodeID.AsString() == "1" {
+			require.InDelta(t, 5.0, dp.Value, 0)
+		}
+	}
+}
+
+func TestCardinalityLimit(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithCardinalityLimit(2)).WithSystem("ydb")
+
+	counter := cfg.CounterVec("requests", "endpoint")
+	gauge := cfg.GaugeVec("conns", "endpoint")
+	for _, endpoint := range []string{"a", "b", "c", "d", "a", "c", "d", "c"} {
+		counter.With(map[string]string{"endpoint": endpoint}).Inc()
+		gauge.With(map[string]string{"endpoint": endpoint}).Add(1)
+	}
+
+	collected := collectMetrics(t, reader)
+
+	sum, ok := collected["ydb_requests"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	counts := map[string]int64{}
+	for _, dp := range sum.DataPoints {
+		if overflow, _ := dp.Attributes.Value(overflowAttribute); overflow.AsBool() {
+			counts["overflow"] = dp.Value
+			require.Equal(t, 1, dp.Attributes.Len())
+
+			continue
+		}
+		endpoint, _ := dp.Attributes.Value("endpoint")
+		counts[endpoint.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]int64{"a": 2, "b": 1, "overflow": 5}, counts)
+
+	conns, ok := collected["ydb_conns"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, conns.DataPoints, 3)
+
+	folded, ok := collected[overflowMetricName].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	foldedBy := map[string]int64{}
+	for _, dp := range folded.DataPoints {
+		name, _ := dp.Attributes.Value(overflowMetricLabel)
+		foldedBy[name.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]int64{"ydb_requests": 2, "ydb_conns": 2}, foldedBy)
+}
+
+func TestCardinalityLimitKeepsConstAttributes(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithSemconv(), WithCardinalityLimit(1)).
+		WithSystem("ydb").WithSystem("query").WithSystem("tx").WithSystem("exec")
+
+	timer := cfg.TimerVec("latency", "label")
+	for _, label := range []string{"a", "b"} {
+		timer.With(map[string]string{"label": label}).Record(time.Second)
+	}
+
+	duration, ok := collectMetrics(t, reader)[dbOperationDurationMetric].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 2)
+
+	overflowed := 0
+	for _, dp := range duration.DataPoints {
+		system, _ := dp.Attributes.Value(dbSystemNameAttribute)
+		require.Equal(t, dbSystemName, system.AsString())
+		operation, _ := dp.Attributes.Value(dbOperationNameAttribute)
+		require.Equal(t, "query.tx.exec", operation.AsString())
+
+		if overflow, _ := dp.Attributes.Value(overflowAttribute); overflow.AsBool() {
+			require.Equal(t, 3, dp.Attributes.Len())
+			overflowed++
+		}
+	}
+	require.Equal(t, 1, overflowed)
+}
+
+func TestMetricDescriptions(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"),
+		WithNamespace("app"),
+		WithSeparator("."),
+		WithMetricDescriptions(map[string]MetricDescription{
+			"ydb.driver.conns": {Description: "Open connections", Unit: "{conn}"},
+			"ydb.custom":       {Description: "Custom metric", Unit: "By"},
+		}),
+	).WithSystem("ydb")
+
+	cfg.GaugeVec("info", "version").With(map[string]string{"version": "v3"}).Set(1)
+	cfg.WithSystem("driver").GaugeVec("conns", "endpoint").With(nil).Set(1)
+	cfg.WithSystem("query").WithSystem("do").CounterVec("errs", "status").With(nil).Inc()
+	cfg.WithSystem("retry").TimerVec("latency").With(nil).Record(time.Second)
+	cfg.HistogramVec("custom", []float64{1}).With(nil).Record(1)
+	cfg.CounterVec("unknown").With(nil).Inc()
+
+	collected := collectMetrics(t, reader)
+
+	for name, expected := range map[string]MetricDescription{
+		"app.ydb.info":          {"Information about ydb-go-sdk version, value is always 1", "1"},
+		"app.ydb.driver.conns":  {"Open connections", "{conn}"},
+		"app.ydb.query.do.errs": {"Number of errors of ydb query.do operations by status", "{error}"},
+		"app.ydb.retry.latency": {"Latency of ydb retry operations", "s"},
+		"app.ydb.custom":        {"Custom metric", "By"},
+		"app.ydb.unknown":       {"ydb-go-sdk counter", ""},
+	} {
+		require.Contains(t, collected, name)
+		require.Equal(t, expected.Description, collected[name].Description, name)
+		require.Equal(t, expected.Unit, collected[name].Unit, name)
+	}
+}
+
+var errInstrument = errors.New("instrument is refused")
+
+// errorMeter refuses to create instruments.
+type errorMeter struct {
+	noop.Meter
+}
+
+func (errorMeter) Int64Counter(string, ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return nil, errInstrument
+}
+
+func (errorMeter) Float64ObservableGauge(string, ...metric.Float64ObservableGaugeOption) (
+	metric.Float64ObservableGauge, error,
+) {
+	return nil, errInstrument
+}
+
+func (errorMeter) Float64Histogram(string, ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return nil, errInstrument
+}
+
+func (errorMeter) RegisterCallback(metric.Callback, ...metric.Observable) (metric.Registration, error) {
+	return nil, errInstrument
+}
+
+func TestMetricsErrorHandler(t *testing.T) {
+	var errs []error
+	cfg := metricsConfigFromOpts(errorMeter{},
+		WithCardinalityLimit(1),
+		WithErrorHandler(func(err error) {
+			errs = append(errs, err)
+		}),
+	)
+
+	require.NotPanics(t, func() {
+		cfg.CounterVec("requests", "status").With(map[string]string{"status": "ok"}).Inc()
+		cfg.GaugeVec("sessions").With(nil).Set(1)
+		cfg.TimerVec("latency").With(nil).Record(time.Second)
+		cfg.HistogramVec("attempts", []float64{1, 2}).With(nil).Record(1)
+	})
+
+	// cardinality limit counter, counter, gauge with its callback, timer and histogram
+	require.Len(t, errs, 6)
+	for _, err := range errs {
+		require.ErrorIs(t, err, errInstrument)
+	}
+}
+
+func TestWithSystemSharesInstruments(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb")
+
+	const goroutines = 64
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			query := cfg.WithSystem("query")
+			query.GaugeVec("sessions").With(nil).Add(1)
+			query.CounterVec("errs", "status").With(map[string]string{"status": "ok"}).Inc()
+		}()
+	}
+	wg.Wait()
+
+	require.Same(t, cfg.WithSystem("query").GaugeVec("sessions"), cfg.WithSystem("query").GaugeVec("sessions"))
+
+	collected := collectMetrics(t, reader)
+
+	gauge, ok := collected["ydb_query_sessions"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	require.InDelta(t, float64(goroutines), gauge.DataPoints[0].Value, 0)
+
+	sum, ok := collected["ydb_query_errs"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, int64(goroutines), sum.DataPoints[0].Value)
+}
+
+func TestMetricViews(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"),
+		WithMetricRename("ydb_query_session_count", "ydb_query_sessions"),
+		WithMetricDrop("ydb_table_*"),
+		WithMetricAttributes("ydb_driver_conn_*", "status"),
+	).WithSystem("ydb")
+
+	cfg.WithSystem("query").WithSystem("session").GaugeVec("count").With(nil).Set(3)
+	cfg.WithSystem("table").GaugeVec("sessions", "node_id").With(map[string]string{"node_id": "1"}).Set(1)
+	statuses := cfg.WithSystem("driver").WithSystem("conn").CounterVec("request_statuses", "status", "endpoint")
+	statuses.With(map[string]string{"status": "OK", "endpoint": "a"}).Inc()
+	statuses.With(map[string]string{"status": "OK", "endpoint": "b", "extra": "x"}).Inc()
+
+	collected := collectMetrics(t, reader)
+	require.NotContains(t, collected, "ydb_query_session_count")
+	require.NotContains(t, collected, "ydb_table_sessions")
+
+	sessions, ok := collected["ydb_query_sessions"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.InDelta(t, 3.0, sessions.DataPoints[0].Value, 0)
+	require.Equal(t, "Number of query sessions", collected["ydb_query_sessions"].Description)
+
+	sum, ok := collected["ydb_driver_conn_request_statuses"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	require.Equal(t, int64(2), sum.DataPoints[0].Value)
+	require.Equal(t, 1, sum.DataPoints[0].Attributes.Len())
+}
+
+func TestSemconvMetrics(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithSemconv()).WithSystem("ydb")
+
+	query := cfg.WithSystem("query")
+	size := query.WithSystem("pool").WithSystem("size")
+	size.GaugeVec("idle").With(nil).Set(3)
+	size.GaugeVec("in_use").With(nil).Set(2)
+	query.WithSystem("tx").WithSystem("exec").TimerVec("latency", "label").With(map[string]string{"label": "tx"}).
+		Record(time.Second)
+	query.WithSystem("do").WithSystem("exec").TimerVec("latency", "label").With(map[string]string{"label": "do"}).
+		Record(time.Second)
+	query.WithSystem("do").TimerVec("latency", "label").With(map[string]string{"label": "do"}).Record(time.Second)
+	query.WithSystem("do").CounterVec("errs", "status").With(map[string]string{"status": "OK"}).Inc()
+	cfg.WithSystem("retry").TimerVec("latency", "retry_label").With(nil).Record(time.Second)
+
+	collected := collectMetrics(t, reader)
+	require.Contains(t, collected, "ydb_query_do_errs")
+	require.Contains(t, collected, "ydb_query_do_latency")
+	require.Contains(t, collected, "ydb_retry_latency")
+
+	count, ok := collected[dbConnectionCountMetric].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+
+	states := map[string]float64{}
+	for _, dp := range count.DataPoints {
+		system, _ := dp.Attributes.Value(dbSystemNameAttribute)
+		require.Equal(t, dbSystemName, system.AsString())
+		pool, _ := dp.Attributes.Value(dbConnectionPoolNameAttribute)
+		require.Equal(t, "query", pool.AsString())
+		state, _ := dp.Attributes.Value(dbConnectionStateAttribute)
+		states[state.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]float64{"idle": 3, "used": 2}, states)
+
+	duration, ok := collected[dbOperationDurationMetric].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Equal(t, "s", collected[dbOperationDurationMetric].Unit)
+
+	operations := map[string]string{}
+	for _, dp := range duration.DataPoints {
+		operation, _ := dp.Attributes.Value(dbOperationNameAttribute)
+		label, _ := dp.Attributes.Value("label")
+		operations[operation.AsString()] = label.AsString()
+	}
+	require.Equal(t, map[string]string{"query.tx.exec": "tx", "query.exec": "do"}, operations)
+}
+
+func TestSemconvMetricsWithQueryOperations(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithSemconv(), WithQueryOperationMetrics())
+
+	cfg.WithSystem("ydb").WithSystem("query").WithSystem("tx").WithSystem("exec").TimerVec("latency", "label").
+		With(nil).Record(time.Second)
+	newQueryOperations(cfg).queryTrace().OnTxExec(trace.QueryTxExecStartInfo{
+		Query: "SELECT * FROM series",
+	})(trace.QueryTxExecDoneInfo{})
+
+	collected := collectMetrics(t, reader)
+	require.Contains(t, collected, "ydb_query_tx_exec_latency")
+
+	duration, ok := collected[dbOperationDurationMetric].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+
+	operation, _ := duration.DataPoints[0].Attributes.Value(dbOperationNameAttribute)
+	require.Equal(t, "SELECT", operation.AsString())
+}
+
+func TestSemconvPoolMetrics(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	pools := newPoolMetrics(metricsConfigFromOpts(provider.Meter("test"), WithSemconv()))
+
+	pools.tableTrace().OnPoolStateChange(trace.TablePoolStateChangeInfo{Limit: 10, Index: 5, Idle: 2})
+	pools.tableTrace().OnPoolGet(trace.TablePoolGetStartInfo{})(trace.TablePoolGetDoneInfo{})
+	pools.queryTrace().OnPoolGet(trace.QueryPoolGetStartInfo{})(trace.QueryPoolGetDoneInfo{})
+
+	collected := collectMetrics(t, reader)
+
+	count, ok := collected[dbConnectionCountMetric].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, count.DataPoints, 1)
+	require.InDelta(t, 3.0, count.DataPoints[0].Value, 0)
+	state, _ := count.DataPoints[0].Attributes.Value(dbConnectionStateAttribute)
+	require.Equal(t, "used", state.AsString())
+
+	wait, ok := collected[dbConnectionWaitTimeMetric].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Equal(t, "s", collected[dbConnectionWaitTimeMetric].Unit)
+
+	counts := map[string]uint64{}
+	for _, dp := range wait.DataPoints {
+		pool, _ := dp.Attributes.Value(dbConnectionPoolNameAttribute)
+		counts[pool.AsString()] = dp.Count
+	}
+	require.Equal(t, map[string]uint64{"query": 1, "table": 1}, counts)
+}
+
+func TestSemconvMetricDescriptions(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithSemconv(),
+		WithMetricDescriptions(map[string]MetricDescription{
+			"ydb.query.pool.size.limit":    {Description: "Query sessions limit", Unit: "{session}"},
+			dbConnectionPendingMetric:      {Description: "Waiters for session", Unit: "{waiter}"},
+			"db.client.connection.unknown": {Description: "Unused", Unit: "1"},
+		}),
+	).WithSystem("ydb").WithSystem("query").WithSystem("pool").WithSystem("size")
+
+	cfg.GaugeVec("limit").With(nil).Set(10)
+	cfg.GaugeVec("waiters_queue").With(nil).Set(1)
+
+	collected := collectMetrics(t, reader)
+	require.Equal(t, "Query sessions limit", collected[dbConnectionMaxMetric].Description)
+	require.Equal(t, "{session}", collected[dbConnectionMaxMetric].Unit)
+	require.Equal(t, "Waiters for session", collected[dbConnectionPendingMetric].Description)
+	require.Equal(t, "{waiter}", collected[dbConnectionPendingMetric].Unit)
+}
+
+func TestExponentialHistogramView(t *testing.T) {
+	reader := sdkMetric.NewManualReader()
+	provider := sdkMetric.NewMeterProvider(
+		sdkMetric.WithReader(reader),
+		sdkMetric.WithView(ExponentialHistogramView("test", "ydb_*", TimerHistograms, 160, 20)),
+	)
+	cfg := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb").WithSystem("query")
+
+	cfg.TimerVec("latency").With(nil).Record(time.Second)
+	cfg.HistogramVec("attempts", []float64{1, 2}).With(nil).Record(1)
+
+	app, err := provider.Meter("app").Float64Histogram("ydb_app_latency", metric.WithUnit("s"))
+	require.NoError(t, err)
+	app.Record(context.Background(), 1)
+
+	collected := collectMetrics(t, reader)
+
+	_, ok := collected["ydb_app_latency"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+
+	latency, ok := collected["ydb_query_latency"].Data.(metricdata.ExponentialHistogram[float64])
+	require.True(t, ok)
+	require.Len(t, latency.DataPoints, 1)
+	require.Equal(t, uint64(1), latency.DataPoints[0].Count)
+	require.Equal(t, "s", collected["ydb_query_latency"].Unit)
+
+	_, ok = collected["ydb_query_attempts"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+}
+
+func TestExponentialHistogramViewKinds(t *testing.T) {
+	view := ExponentialHistogramView("", "", ValueHistograms, 160, 20)
+	scope := instrumentation.Scope{Name: instrumentationName}
+
+	_, ok := view(sdkMetric.Instrument{
+		Name: "ydb_latency", Kind: sdkMetric.InstrumentKindHistogram, Unit: "s", Scope: scope,
+	})
+	require.False(t, ok)
+
+	_, ok = view(sdkMetric.Instrument{
+		Name: "ydb_attempts", Kind: sdkMetric.InstrumentKindHistogram, Unit: "{attempt}",
+		Scope: instrumentation.Scope{Name: "app"},
+	})
+	require.False(t, ok)
+
+	stream, ok := view(sdkMetric.Instrument{
+		Name: "ydb_attempts", Kind: sdkMetric.InstrumentKindHistogram, Unit: "{attempt}", Scope: scope,
+	})
+	require.True(t, ok)
+	require.Equal(t, "ydb_attempts", stream.Name)
+	require.Equal(t, sdkMetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20}, stream.Aggregation)
+
+	_, ok = view(sdkMetric.Instrument{Name: "ydb_errs", Kind: sdkMetric.InstrumentKindCounter, Scope: scope})
+	require.False(t, ok)
+}
+
+func TestTimerUnit(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithTimerUnit(TimerUnitMilliseconds)).WithSystem("ydb")
+
+	cfg.TimerVec("latency").With(nil).Record(1500 * time.Microsecond)
+
+	collected := collectMetrics(t, reader)
+	require.Equal(t, "ms", collected["ydb_latency"].Unit)
+
+	latency, ok := collected["ydb_latency"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, latency.DataPoints, 1)
+	require.InDelta(t, 1.5, latency.DataPoints[0].Sum, 1e-9)
+	require.Equal(t, TimerUnitMilliseconds.buckets(defaultTimerBuckets), latency.DataPoints[0].Bounds)
+	require.InDelta(t, 1000, latency.DataPoints[0].Bounds[13], 1e-9)
+}
+
+func TestTimerUnitSemconvSeconds(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithSemconv(), WithTimerUnit(TimerUnitMilliseconds)).
+		WithSystem("ydb").WithSystem("query")
+
+	cfg.WithSystem("tx").WithSystem("exec").TimerVec("latency").With(nil).Record(1500 * time.Millisecond)
+	cfg.WithSystem("pool").WithSystem("get").TimerVec("latency").With(nil).Record(1500 * time.Millisecond)
+
+	collected := collectMetrics(t, reader)
+	for _, name := range []string{dbOperationDurationMetric, dbConnectionWaitTimeMetric} {
+		require.Equal(t, "s", collected[name].Unit)
+
+		histogram, ok := collected[name].Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, histogram.DataPoints, 1)
+		require.InDelta(t, 1.5, histogram.DataPoints[0].Sum, 1e-9)
+		require.Equal(t, defaultTimerBuckets, histogram.DataPoints[0].Bounds)
+	}
+}
+
+func TestTimerUnitKeepsCustomBuckets(t *testing.T) {
+	opts := []metricsOption{WithTimerUnit(TimerUnitMicroseconds), WithTimerBuckets([]float64{10, 100})}
+	cfg, _ := metricsConfigFromOpts(nil, opts...).(*metricsConfig)
+	require.Equal(t, []float64{10, 100}, cfg.timerBuckets)
+
+	cfg, _ = metricsConfigFromOpts(nil, WithTimerUnit("min")).(*metricsConfig)
+	require.Equal(t, TimerUnitSeconds, cfg.timerUnit)
+	require.Equal(t, defaultTimerBuckets, cfg.timerBuckets)
+}
+
+func TestSeriesCache(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb")
+
+	counter := cfg.CounterVec("errs", "status")
+	counter.With(map[string]string{"status": "OK"}).Inc()
+	counter.With(map[string]string{"status": "OK"}).Inc()
+	counter.With(map[string]string{"status": "ABORTED"}).Inc()
+	counter.With(map[string]string{"status": "context/Canceled"}).Inc()
+
+	vec, ok := counter.(*counterVec)
+	require.True(t, ok)
+	require.Len(t, vec.series.options, 3)
+
+	for i := range maxCachedSeries + 10 {
+		counter.With(map[string]string{"status": strconv.Itoa(i)}).Inc()
+	}
+	require.Len(t, vec.series.options, maxCachedSeries)
+
+	sum, ok := collectMetrics(t, reader)["ydb_errs"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, maxCachedSeries+13)
+
+	for _, dp := range sum.DataPoints {
+		if status, _ := dp.Attributes.Value("status"); status.AsString() == "context/Canceled" {
+			cancelled, _ := dp.Attributes.Value(cancelledAttribute)
+			require.True(t, cancelled.AsBool())
+		}
+	}
+}
+
+func BenchmarkCounterInc(b *testing.B) {
+	provider, _ := newTestMeterProvider()
+	counter := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb").
+		CounterVec("errs", "status").With(map[string]string{"status": "OK"})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		counter.Inc()
+	}
+}
+
+func BenchmarkTimerRecord(b *testing.B) {
+	provider, _ := newTestMeterProvider()
+	timer := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb").
+		TimerVec("latency", "method").With(map[string]string{"method": "ExecuteQuery"})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		timer.Record(time.Millisecond)
+	}
+}
+
+func BenchmarkHistogramRecord(b *testing.B) {
+	provider, _ := newTestMeterProvider()
+	histogram := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb").
+		HistogramVec("attempts", []float64{1, 2, 5}, "method").With(map[string]string{"method": "Do"})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		histogram.Record(1)
+	}
+}
+
+func BenchmarkCounterWithInc(b *testing.B) {
+	provider, _ := newTestMeterProvider()
+	counter := metricsConfigFromOpts(provider.Meter("test")).WithSystem("ydb").CounterVec("errs", "status")
+	labels := map[string]string{"status": "OK"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		counter.With(labels).Inc()
+	}
+}
@@ -1,8 +1,16 @@
 package ydb
 
 import (
+	"maps"
+	"reflect"
+	"time"
+
 	"github.com/ydb-platform/ydb-go-sdk/v3/log"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // tracesOption configures OpenTelemetry spans adapter.
@@ -82,7 +90,7 @@ func (o timerBucketsOption) applyMetricsOption(c *metricsConfig) {
 	c.timerBuckets = append([]float64(nil), o.timerBuckets...)
 }
 
-// WithTimerBuckets sets histogram buckets for timer metrics.
+// WithTimerBuckets sets histogram buckets for timer metrics in units of timers, seconds by default.
 func WithTimerBuckets(timerBuckets []float64) metricsOption {
 	return timerBucketsOption{
 		timerBuckets: append([]float64(nil), timerBuckets...),
@@ -99,3 +107,323 @@ func (logQueryOption) applyLoggerOption(c *loggerConfig) {
 func WithLogQuery() loggerOption {
 	return logQueryOption{}
 }
+
+type attributeConverterOption struct {
+	valueType reflect.Type
+	convert   attributeConverter
+}
+
+func (o attributeConverterOption) applyTracesOption(c *adapter) {
+	if c.converters == nil {
+		c.converters = attributeConverters{}
+	}
+
+	c.converters[o.valueType] = o.convert
+}
+
+// WithAttributeConverter registers converter of span field values with type T into span attributes.
+// Registered converters take precedence over built-in conversions.
+func WithAttributeConverter[T any](convert func(key string, value T) []attribute.KeyValue) tracesOption {
+	return attributeConverterOption{
+		valueType: reflect.TypeFor[T](),
+		convert: func(key string, value any) []attribute.KeyValue {
+			return convert(key, value.(T)) //nolint:forcetypeassert
+		},
+	}
+}
+
+type eventsLimitOption struct {
+	limit int
+}
+
+func (o eventsLimitOption) applyTracesOption(c *adapter) {
+	c.eventsLimit = o.limit
+}
+
+// WithEventsLimit limits number of events added to each span by Log calls.
+// Events over the limit are not recorded, instead span gets ydb.events.dropped counters on End.
+// Zero limit means unlimited.
+func WithEventsLimit(limit int) tracesOption {
+	return eventsLimitOption{limit: limit}
+}
+
+type querySpanNamesOption struct{}
+
+func (querySpanNamesOption) applyTracesOption(c *adapter) {
+	c.querySpanNames = true
+}
+
+// WithQuerySpanNames names query spans by statement type and table derived from query text,
+// like "UPSERT series". Original operation name is kept in ydb.operation attribute.
+func WithQuerySpanNames() tracesOption {
+	return querySpanNamesOption{}
+}
+
+type sanitizedQueryTextOption struct{}
+
+func (sanitizedQueryTextOption) applyTracesOption(c *adapter) {
+	c.sanitizeQueryText = true
+}
+
+// WithSanitizedQueryText replaces string and numeric literals and comment bodies in query text
+// attributes of spans with '?' placeholders. By default query text is kept as is.
+func WithSanitizedQueryText() tracesOption {
+	return sanitizedQueryTextOption{}
+}
+
+type correlationOnlyOption struct{}
+
+func (correlationOnlyOption) applyTracesOption(c *adapter) {
+	c.correlationOnly = true
+}
+
+// WithCorrelationOnly disables creation of ydb-go-sdk spans. Trace and span IDs of the incoming
+// context are still passed into ydb log fields for correlation of log records with traces.
+func WithCorrelationOnly() tracesOption {
+	return correlationOnlyOption{}
+}
+
+type contextErrorStatusOption struct {
+	code codes.Code
+}
+
+func (o contextErrorStatusOption) applyTracesOption(c *adapter) {
+	c.contextErrorStatus = o.code
+}
+
+// WithContextErrorStatus sets span status for errors caused by context cancellation or deadline.
+// Default status is codes.Unset, so cancelled operations are not reported as failures.
+func WithContextErrorStatus(code codes.Code) tracesOption {
+	return contextErrorStatusOption{code: code}
+}
+
+type okStatusOption struct{}
+
+func (okStatusOption) applyTracesOption(c *adapter) {
+	c.okStatus = true
+}
+
+// WithOKStatus sets codes.Ok status on spans which are ended without errors.
+func WithOKStatus() tracesOption {
+	return okStatusOption{}
+}
+
+type retryDecisionsOption struct{}
+
+func (retryDecisionsOption) applyTracesOption(c *adapter) {
+	c.retryDecisions = true
+}
+
+// WithRetryDecisions records ydb.idempotent attribute of operations and ydb.retry.decision
+// attribute (retry, retry_with_new_session or give_up) of failed spans. Nested spans of retry
+// operation use its idempotency, and span of retry loop gets give_up only when the loop ends with error.
+func WithRetryDecisions() tracesOption {
+	return retryDecisionsOption{}
+}
+
+type leakDetectorOption struct {
+	detector *leakDetector
+}
+
+func (o leakDetectorOption) applyTracesOption(c *adapter) {
+	c.leaks = o.detector
+}
+
+// WithLeakDetector enables tracking of open ydb-go-sdk spans. Spans which are open longer than limit
+// are reported once by a warning log record, number of open spans by operation is reported by
+// ydb.spans.open gauge. On driver close dangling spans of the driver are ended with ydb.span.abandoned
+// attribute. If logger or meter is nil, global providers with scope "ydb-go-sdk" are used.
+// The detector is created once by the option and shared by all adapters the option is applied to,
+// spans of other drivers are kept when one of them is closed.
+func WithLeakDetector(limit time.Duration, logger otelLog.Logger, meter metric.Meter) tracesOption {
+	return leakDetectorOption{
+		detector: newLeakDetector(limit, logger, meter),
+	}
+}
+
+type slowQueryPlansOption struct {
+	threshold time.Duration
+	interval  time.Duration
+	logger    otelLog.Logger
+}
+
+func (o slowQueryPlansOption) applyTracesOption(c *adapter) {
+	c.plans = newPlanCapture(o.threshold, o.interval, o.logger)
+}
+
+// WithSlowQueryPlans records plans of sampled queries which run longer than threshold as
+// ydb.query.plan span events. At most one plan is recorded per interval. If query span is already
+// ended when plan arrives, plan is emitted as a warning log record linked to the span.
+// YDB returns plans only for queries executed with query.WithStatsMode(query.StatsModeFull, nil),
+// so plan collection is opt-in per query. If logger is nil, global provider with scope "ydb-go-sdk" is used.
+func WithSlowQueryPlans(threshold, interval time.Duration, logger otelLog.Logger) tracesOption {
+	return slowQueryPlansOption{
+		threshold: threshold,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+type driverNameOption struct {
+	name string
+}
+
+func (o driverNameOption) applyTracesOption(c *adapter) {
+	c.identity = newDriverIdentity(o.name)
+}
+
+func (o driverNameOption) applyMetricsOption(c *metricsConfig) {
+	c.identity = newDriverIdentity(o.name)
+}
+
+func (o driverNameOption) applyLoggerOption(c *loggerConfig) {
+	c.identity = newDriverIdentity(o.name)
+}
+
+// WithDriverName attaches identity of ydb-go-sdk driver to spans, metrics and log records:
+// ydb.driver.name attribute with name and db.namespace, server.address and server.port attributes
+// detected on driver init. Use distinct names for drivers which share tracer, meter or logger.
+// Metrics recorded before driver init (like ydb_info) have only ydb.driver.name attribute.
+func WithDriverName(name string) Option {
+	return driverNameOption{name: name}
+}
+
+type cardinalityLimitOption struct {
+	limit int
+}
+
+func (o cardinalityLimitOption) applyMetricsOption(c *metricsConfig) {
+	c.maxLabelSets = o.limit
+}
+
+// WithCardinalityLimit limits number of distinct label sets of each metric. Label sets over the limit
+// are folded into a single series with otel.metric.overflow=true attribute and counted by
+// ydb.metrics.overflow counter with metric attribute. Zero limit means unlimited.
+func WithCardinalityLimit(limit int) metricsOption {
+	return cardinalityLimitOption{limit: limit}
+}
+
+type metricDescriptionsOption struct {
+	descriptions map[string]MetricDescription
+}
+
+func (o metricDescriptionsOption) applyMetricsOption(c *metricsConfig) {
+	if c.descriptions == nil {
+		c.descriptions = metricDescriptions{}
+	}
+
+	maps.Copy(c.descriptions, o.descriptions)
+}
+
+// WithMetricDescriptions extends or overrides built-in catalog of descriptions and units of ydb-go-sdk
+// metrics. Keys are dotted paths of subsystems and metric name like "ydb.query.session.count",
+// regardless of namespace and separator options. Units of timers follow WithTimerUnit and are not
+// overridden, timers mapped by WithSemconv are always reported in seconds.
+func WithMetricDescriptions(descriptions map[string]MetricDescription) metricsOption {
+	return metricDescriptionsOption{
+		descriptions: maps.Clone(descriptions),
+	}
+}
+
+type errorHandlerOption struct {
+	handler func(error)
+}
+
+func (o errorHandlerOption) applyMetricsOption(c *metricsConfig) {
+	c.errorHandler = o.handler
+}
+
+// WithErrorHandler sets handler of errors of metric instruments creation. Instruments which
+// failed to be created are replaced by no-op instruments. Default handler is otel.Handle.
+func WithErrorHandler(handler func(error)) metricsOption {
+	return errorHandlerOption{handler: handler}
+}
+
+type metricViewOption struct {
+	view metricView
+}
+
+func (o metricViewOption) applyMetricsOption(c *metricsConfig) {
+	c.views = append(c.views, o.view)
+}
+
+// WithMetricRename renames instrument with name. Name includes namespace and separator,
+// like "ydb_query_session_count".
+func WithMetricRename(name, newName string) metricsOption {
+	return metricViewOption{
+		view: metricView{
+			pattern: name,
+			rename:  newName,
+		},
+	}
+}
+
+// WithMetricDrop disables instruments with names matching glob pattern of path.Match,
+// like "ydb_table_*".
+func WithMetricDrop(pattern string) metricsOption {
+	return metricViewOption{
+		view: metricView{
+			pattern: pattern,
+			drop:    true,
+		},
+	}
+}
+
+// WithMetricAttributes restricts attributes of instruments with names matching glob pattern
+// of path.Match to allow-list of keys. Attributes added by adapter like ydb.driver.name are kept.
+func WithMetricAttributes(pattern string, keys ...string) metricsOption {
+	return metricViewOption{
+		view: metricView{
+			pattern:       pattern,
+			attributeKeys: append([]string{}, keys...),
+		},
+	}
+}
+
+type semconvOption struct{}
+
+func (semconvOption) applyMetricsOption(c *metricsConfig) {
+	c.semconv = true
+}
+
+// WithSemconv maps ydb-go-sdk metrics to database client metrics of OpenTelemetry semantic conventions:
+// latencies of single query executions are reported as db.client.operation.duration with
+// db.operation.name like "query.tx.exec", sessions pools as db.client.connection.count, db.client.connection.max,
+// db.client.connection.pending_requests, db.client.connection.create_time and
+// db.client.connection.wait_time with db.client.connection.pool.name "query" or "table".
+// All mapped metrics get db.system.name "ydb".
+// Other metrics keep ydb-go-sdk names. Use WithDriverName to add db.namespace and server.address.
+func WithSemconv() metricsOption {
+	return semconvOption{}
+}
+
+type timerUnitOption struct {
+	unit TimerUnit
+}
+
+func (o timerUnitOption) applyMetricsOption(c *metricsConfig) {
+	if o.unit.duration() != 0 {
+		c.timerUnit = o.unit
+	}
+}
+
+// WithTimerUnit sets unit of timer metrics: TimerUnitSeconds (default), TimerUnitMilliseconds or
+// TimerUnitMicroseconds. Recorded values and default timer buckets are scaled to unit. Unknown units
+// are ignored. Timers mapped by WithSemconv are always reported in seconds.
+func WithTimerUnit(unit TimerUnit) metricsOption {
+	return timerUnitOption{
+		unit: unit,
+	}
+}
+
+type queryOperationMetricsOption struct{}
+
+func (queryOperationMetricsOption) applyMetricsOption(c *metricsConfig) {
+	c.queryOperations = true
+}
+
+// WithQueryOperationMetrics enables ydb_query_operation_latency timer of query executions labeled by
+// db.operation.name and db.collection.name derived from query text, like SELECT and series.
+func WithQueryOperationMetrics() metricsOption {
+	return queryOperationMetricsOption{}
+}
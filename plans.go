@@ -0,0 +1,118 @@
+package ydb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	otelLog "go.opentelemetry.io/otel/log"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	queryPlanEventName      = "ydb.query.plan"
+	queryPlanAttribute      = "ydb.query.plan"
+	queryDurationAttribute  = "ydb.query.duration"
+	slowQueryPlanLogMessage = "ydb query is slower than threshold"
+)
+
+// planCapture records plans of queries which are slower than threshold.
+// Plans are returned by YDB only for queries executed with query.WithStatsMode(query.StatsModeFull, ...)
+// or query.StatsModeProfile, so capture is opt-in per query and costs nothing for other queries.
+type planCapture struct {
+	threshold time.Duration
+	interval  time.Duration
+	logger    otelLog.Logger
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func newPlanCapture(threshold, interval time.Duration, logger otelLog.Logger) *planCapture {
+	return &planCapture{
+		threshold: threshold,
+		interval:  interval,
+		logger:    loggerFrom(logger),
+	}
+}
+
+// allow reports whether plan may be recorded: at most one plan is recorded per interval.
+func (p *planCapture) allow(now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.last.IsZero() && now.Sub(p.last) < p.interval {
+		return false
+	}
+
+	p.last = now
+
+	return true
+}
+
+// record attaches plan of slow query to span of ctx. If span is already ended, plan is emitted
+// as log record linked to span.
+func (p *planCapture) record(ctx context.Context, stats *Ydb_TableStats.QueryStats) {
+	if stats.GetQueryPlan() == "" || !enabledInContext(ctx, trace.QueryEvents) {
+		return
+	}
+
+	duration := time.Duration(stats.GetTotalDurationUs()) * time.Microsecond
+	if duration < p.threshold {
+		return
+	}
+
+	s := otelTrace.SpanFromContext(ctx)
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+
+	now := time.Now()
+	if !p.allow(now) {
+		return
+	}
+
+	if s.IsRecording() {
+		s.AddEvent(queryPlanEventName, otelTrace.WithAttributes(
+			attribute.String(queryPlanAttribute, stats.GetQueryPlan()),
+			attribute.Float64(queryDurationAttribute, duration.Seconds()),
+		))
+
+		return
+	}
+
+	spanCtx := s.SpanContext()
+
+	record := otelLog.Record{}
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(otelLog.SeverityWarn)
+	record.SetSeverityText("WARN")
+	record.SetBody(otelLog.StringValue(slowQueryPlanLogMessage))
+	record.AddAttributes(
+		otelLog.String(queryPlanAttribute, stats.GetQueryPlan()),
+		otelLog.Int64("duration_ms", duration.Milliseconds()),
+		otelLog.String(traceIDLogField, spanCtx.TraceID().String()),
+		otelLog.String(spanIDLogField, spanCtx.SpanID().String()),
+	)
+
+	p.logger.Emit(ctx, record)
+}
+
+// queryTrace returns query trace which captures plans from query result parts.
+func (p *planCapture) queryTrace() trace.Query {
+	return trace.Query{
+		OnResultNextPart: func(info trace.QueryResultNextPartStartInfo) func(trace.QueryResultNextPartDoneInfo) {
+			ctx := *info.Context
+
+			return func(info trace.QueryResultNextPartDoneInfo) {
+				if info.Error == nil && info.Stats != nil {
+					p.record(ctx, info.Stats)
+				}
+			}
+		},
+	}
+}
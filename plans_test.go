@@ -0,0 +1,54 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func nextPart(ctx context.Context, q trace.Query, stats *Ydb_TableStats.QueryStats) {
+	q.OnResultNextPart(trace.QueryResultNextPartStartInfo{Context: &ctx})(trace.QueryResultNextPartDoneInfo{
+		Stats: stats,
+	})
+}
+
+func TestSlowQueryPlans(t *testing.T) {
+	capture := &captureLogger{}
+	a, recorder := newTestSpansAdapter(WithSlowQueryPlans(time.Second, time.Hour, capture))
+	q := a.plans.queryTrace()
+
+	ctx, s := a.Start(context.Background(), "query.Exec")
+	nextPart(ctx, q, &Ydb_TableStats.QueryStats{QueryPlan: "fast", TotalDurationUs: 1000})
+	nextPart(ctx, q, &Ydb_TableStats.QueryStats{QueryPlan: "slow", TotalDurationUs: 2_000_000})
+	nextPart(ctx, q, &Ydb_TableStats.QueryStats{QueryPlan: "limited", TotalDurationUs: 3_000_000})
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Len(t, ended[0].Events(), 1)
+
+	event := ended[0].Events()[0]
+	require.Equal(t, queryPlanEventName, event.Name)
+	attrs := attributesMap(event.Attributes)
+	require.Equal(t, "slow", attrs[queryPlanAttribute].AsString())
+	require.InDelta(t, 2.0, attrs[queryDurationAttribute].AsFloat64(), 1e-9)
+	require.Empty(t, capture.records)
+}
+
+func TestSlowQueryPlansOfEndedSpan(t *testing.T) {
+	capture := &captureLogger{}
+	a, _ := newTestSpansAdapter(WithSlowQueryPlans(time.Second, 0, capture))
+	q := a.plans.queryTrace()
+
+	ctx, s := a.Start(context.Background(), "query.Session.Query")
+	s.End()
+	nextPart(ctx, q, &Ydb_TableStats.QueryStats{QueryPlan: "slow", TotalDurationUs: 2_000_000})
+	nextPart(WithoutTracing(ctx), q, &Ydb_TableStats.QueryStats{QueryPlan: "slow", TotalDurationUs: 2_000_000})
+
+	require.Len(t, capture.records, 1)
+	require.Equal(t, slowQueryPlanLogMessage, capture.records[0].Body().AsString())
+}
@@ -0,0 +1,67 @@
+package ydb
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/metrics"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// poolMetrics records sessions pools metrics which ydb-go-sdk does not report itself: time of
+// waiting for session of query and table pools and number of used sessions of table pool.
+type poolMetrics struct {
+	details   trace.Detailer
+	queryWait metrics.TimerVec
+	tableWait metrics.TimerVec
+	tableUsed metrics.GaugeVec
+}
+
+func newPoolMetrics(config metrics.Config) *poolMetrics {
+	query := config.WithSystem("ydb").WithSystem("query").WithSystem("pool")
+	table := config.WithSystem("ydb").WithSystem("table").WithSystem("pool")
+
+	return &poolMetrics{
+		details:   config,
+		queryWait: query.WithSystem("get").TimerVec("latency"),
+		tableWait: table.WithSystem("get").TimerVec("latency"),
+		tableUsed: table.GaugeVec("in_use"),
+	}
+}
+
+// wait returns function which records time of waiting for session if pool events are enabled.
+func (p *poolMetrics) wait(timer metrics.TimerVec, details trace.Details) func() {
+	if p.details.Details()&details == 0 {
+		return func() {}
+	}
+
+	start := time.Now()
+
+	return func() {
+		timer.With(nil).Record(time.Since(start))
+	}
+}
+
+func (p *poolMetrics) queryTrace() trace.Query {
+	return trace.Query{
+		OnPoolGet: func(trace.QueryPoolGetStartInfo) func(trace.QueryPoolGetDoneInfo) {
+			done := p.wait(p.queryWait, trace.QueryPoolEvents)
+
+			return func(trace.QueryPoolGetDoneInfo) { done() }
+		},
+	}
+}
+
+func (p *poolMetrics) tableTrace() trace.Table {
+	return trace.Table{
+		OnPoolGet: func(trace.TablePoolGetStartInfo) func(trace.TablePoolGetDoneInfo) {
+			done := p.wait(p.tableWait, trace.TablePoolEvents)
+
+			return func(trace.TablePoolGetDoneInfo) { done() }
+		},
+		OnPoolStateChange: func(info trace.TablePoolStateChangeInfo) {
+			if p.details.Details()&trace.TablePoolEvents != 0 {
+				p.tableUsed.With(nil).Set(float64(info.Index - info.Idle))
+			}
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package ydb
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/metrics"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// queryOperations records latency of query executions labeled by db.operation.name and
+// db.collection.name derived from query text.
+type queryOperations struct {
+	latency metrics.TimerVec
+}
+
+func newQueryOperations(config metrics.Config) *queryOperations {
+	config = config.WithSystem("ydb").WithSystem("query").WithSystem("operation")
+
+	return &queryOperations{
+		latency: config.TimerVec("latency", dbOperationNameAttribute, dbCollectionNameAttribute),
+	}
+}
+
+// start analyzes query and returns function which records latency of query execution.
+// Executions with query events disabled by WithDetails or WithoutTracing are not recorded.
+func (o *queryOperations) start(ctx *context.Context, query string) func() {
+	if ctx != nil && !enabledInContext(*ctx, trace.QueryEvents) {
+		return func() {}
+	}
+
+	summary := analyzeYQL(query)
+	labels := map[string]string{
+		dbOperationNameAttribute:  summary.operationName(),
+		dbCollectionNameAttribute: summary.collectionName(),
+	}
+	start := time.Now()
+
+	return func() {
+		o.latency.With(labels).Record(time.Since(start))
+	}
+}
+
+// queryTrace returns query trace which measures executions of queries by query client, sessions
+// and transactions. Client calls do not produce session events, so executions are not counted twice.
+//
+//nolint:funlen
+func (o *queryOperations) queryTrace() trace.Query {
+	return trace.Query{
+		OnExec: func(info trace.QueryExecStartInfo) func(trace.QueryExecDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryExecDoneInfo) { done() }
+		},
+		OnQuery: func(info trace.QueryQueryStartInfo) func(trace.QueryQueryDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryQueryDoneInfo) { done() }
+		},
+		OnQueryResultSet: func(info trace.QueryQueryResultSetStartInfo) func(trace.QueryQueryResultSetDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryQueryResultSetDoneInfo) { done() }
+		},
+		OnQueryRow: func(info trace.QueryQueryRowStartInfo) func(trace.QueryQueryRowDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryQueryRowDoneInfo) { done() }
+		},
+		OnSessionExec: func(info trace.QuerySessionExecStartInfo) func(trace.QuerySessionExecDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QuerySessionExecDoneInfo) { done() }
+		},
+		OnSessionQuery: func(info trace.QuerySessionQueryStartInfo) func(trace.QuerySessionQueryDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QuerySessionQueryDoneInfo) { done() }
+		},
+		OnSessionQueryResultSet: func(
+			info trace.QuerySessionQueryResultSetStartInfo,
+		) func(trace.QuerySessionQueryResultSetDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QuerySessionQueryResultSetDoneInfo) { done() }
+		},
+		OnSessionQueryRow: func(info trace.QuerySessionQueryRowStartInfo) func(trace.QuerySessionQueryRowDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QuerySessionQueryRowDoneInfo) { done() }
+		},
+		OnTxExec: func(info trace.QueryTxExecStartInfo) func(trace.QueryTxExecDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryTxExecDoneInfo) { done() }
+		},
+		OnTxQuery: func(info trace.QueryTxQueryStartInfo) func(trace.QueryTxQueryDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryTxQueryDoneInfo) { done() }
+		},
+		OnTxQueryResultSet: func(info trace.QueryTxQueryResultSetStartInfo) func(trace.QueryTxQueryResultSetDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryTxQueryResultSetDoneInfo) { done() }
+		},
+		OnTxQueryRow: func(info trace.QueryTxQueryRowStartInfo) func(trace.QueryTxQueryRowDoneInfo) {
+			done := o.start(info.Context, info.Query)
+
+			return func(trace.QueryTxQueryRowDoneInfo) { done() }
+		},
+	}
+}
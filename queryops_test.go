@@ -0,0 +1,65 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestQueryOperationMetrics(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithQueryOperationMetrics())
+	q := newQueryOperations(cfg).queryTrace()
+
+	q.OnSessionExec(trace.QuerySessionExecStartInfo{
+		Query: "DECLARE $id AS Uint64; UPSERT INTO `/local/series` (id) VALUES ($id)",
+	})(trace.QuerySessionExecDoneInfo{})
+	q.OnTxQuery(trace.QueryTxQueryStartInfo{Query: "SELECT * FROM series"})(trace.QueryTxQueryDoneInfo{})
+	q.OnTxQuery(trace.QueryTxQueryStartInfo{Query: "SELECT * FROM series"})(trace.QueryTxQueryDoneInfo{})
+
+	latency, ok := collectMetrics(t, reader)["ydb_query_operation_latency"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+
+	counts := map[string]uint64{}
+	for _, dp := range latency.DataPoints {
+		operation, _ := dp.Attributes.Value(dbOperationNameAttribute)
+		collection, _ := dp.Attributes.Value(dbCollectionNameAttribute)
+		counts[operation.AsString()+" "+collection.AsString()] = dp.Count
+	}
+	require.Equal(t, map[string]uint64{"UPSERT /local/series": 1, "SELECT series": 2}, counts)
+}
+
+func TestQueryOperationMetricsSemconv(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	cfg := metricsConfigFromOpts(provider.Meter("test"), WithSemconv())
+
+	newQueryOperations(cfg).queryTrace().OnExec(trace.QueryExecStartInfo{
+		Query: "SELECT * FROM series",
+	})(trace.QueryExecDoneInfo{})
+
+	duration, ok := collectMetrics(t, reader)[dbOperationDurationMetric].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+
+	operation, _ := duration.DataPoints[0].Attributes.Value(dbOperationNameAttribute)
+	require.Equal(t, "SELECT", operation.AsString())
+}
+
+func TestQueryOperationMetricsWithoutTracing(t *testing.T) {
+	provider, reader := newTestMeterProvider()
+	q := newQueryOperations(metricsConfigFromOpts(provider.Meter("test"))).queryTrace()
+
+	ctx := WithoutTracing(context.Background())
+	q.OnExec(trace.QueryExecStartInfo{Context: &ctx, Query: "SELECT 1"})(trace.QueryExecDoneInfo{})
+
+	ctx = WithDetails(context.Background(), trace.QueryEvents)
+	q.OnExec(trace.QueryExecStartInfo{Context: &ctx, Query: "SELECT 1"})(trace.QueryExecDoneInfo{})
+
+	latency, ok := collectMetrics(t, reader)["ydb_query_operation_latency"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, latency.DataPoints, 1)
+	require.Equal(t, uint64(1), latency.DataPoints[0].Count)
+}
@@ -0,0 +1,100 @@
+package ydb
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	otelTrace "go.opentelemetry.io/otel/trace"
+)
+
+// samplingKeys are span attributes of ydb-go-sdk with YDB session and transaction IDs in order of
+// priority. Session ID goes first: transaction lives in one session, but spans of transaction begin
+// carry session ID only.
+var samplingKeys = []attribute.Key{"session_id", "transaction_id"}
+
+var _ sdkTrace.Sampler = (*transactionSampler)(nil)
+
+type transactionSampler struct {
+	bound    uint64
+	fraction float64
+	fallback sdkTrace.Sampler
+}
+
+// TransactionSampler returns sampler which samples given fraction of YDB transactions.
+// The decision is made by hash of session ID (or transaction ID if span has no session ID)
+// from span start attributes, so all spans of the same transaction, from begin to commit, are
+// sampled or dropped together with other spans of its session.
+// Spans without transaction and session IDs are sampled by fallback.
+// If fallback is nil, sdkTrace.TraceIDRatioBased(fraction) is used.
+//
+// Wrap sampler with sdkTrace.ParentBased to keep decisions of parent spans:
+//
+//	sdkTrace.WithSampler(sdkTrace.ParentBased(ydbOtel.TransactionSampler(0.1, nil)))
+func TransactionSampler(fraction float64, fallback sdkTrace.Sampler) sdkTrace.Sampler {
+	fraction = min(max(fraction, 0), 1)
+	if fallback == nil {
+		fallback = sdkTrace.TraceIDRatioBased(fraction)
+	}
+
+	return &transactionSampler{
+		bound:    uint64(fraction * (1 << 63)),
+		fraction: fraction,
+		fallback: fallback,
+	}
+}
+
+func (s *transactionSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	key, ok := samplingKey(p.Attributes)
+	if !ok {
+		return s.fallback.ShouldSample(p)
+	}
+
+	decision := sdkTrace.Drop
+	if s.sampled(key) {
+		decision = sdkTrace.RecordAndSample
+	}
+
+	return sdkTrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: otelTrace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s *transactionSampler) Description() string {
+	return fmt.Sprintf("YDBTransactionSampler{%g,%s}", s.fraction, s.fallback.Description())
+}
+
+// sampled reports whether key hash fits into sampled fraction.
+func (s *transactionSampler) sampled(key string) bool {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	return mix64(h.Sum64())>>1 < s.bound
+}
+
+// mix64 spreads bits of FNV hash which are poorly mixed for IDs with common prefix.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+
+	return x
+}
+
+func samplingKey(attrs []attribute.KeyValue) (string, bool) {
+	for _, key := range samplingKeys {
+		i := slices.IndexFunc(attrs, func(attr attribute.KeyValue) bool {
+			return attr.Key == key && attr.Value.Type() == attribute.STRING && attr.Value.AsString() != ""
+		})
+		if i >= 0 {
+			return attrs[i].Value.AsString(), true
+		}
+	}
+
+	return "", false
+}
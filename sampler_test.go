@@ -0,0 +1,54 @@
+package ydb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTransactionSamplerConsistentPerTransaction(t *testing.T) {
+	sampler := TransactionSampler(0.5, nil)
+
+	var sampled int
+	for i := range 1000 {
+		sessionID := "session-" + strconv.Itoa(i)
+		txID := "tx-" + strconv.Itoa(i)
+
+		begin := sampler.ShouldSample(sdkTrace.SamplingParameters{
+			ParentContext: context.Background(),
+			Name:          "query.Session.Begin",
+			Attributes:    []attribute.KeyValue{attribute.String("session_id", sessionID)},
+		})
+		for _, name := range []string{"query.Transaction.Exec", "query.Transaction.CommitTx"} {
+			result := sampler.ShouldSample(sdkTrace.SamplingParameters{
+				ParentContext: context.Background(),
+				Name:          name,
+				Attributes: []attribute.KeyValue{
+					attribute.String("transaction_id", txID),
+					attribute.String("session_id", sessionID),
+				},
+			})
+			require.Equal(t, begin.Decision, result.Decision, name)
+		}
+
+		if begin.Decision == sdkTrace.RecordAndSample {
+			sampled++
+		}
+	}
+
+	require.InDelta(t, 500, sampled, 100)
+}
+
+func TestTransactionSamplerFallback(t *testing.T) {
+	sampler := TransactionSampler(0.5, sdkTrace.AlwaysSample())
+
+	result := sampler.ShouldSample(sdkTrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "discovery",
+	})
+	require.Equal(t, sdkTrace.RecordAndSample, result.Decision)
+}
@@ -0,0 +1,130 @@
+package ydb
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	dbSystemNameAttribute         = "db.system.name"
+	dbSystemName                  = "ydb"
+	dbConnectionStateAttribute    = "db.client.connection.state"
+	dbConnectionPoolNameAttribute = "db.client.connection.pool.name"
+
+	dbOperationDurationMetric    = "db.client.operation.duration"
+	dbConnectionCountMetric      = "db.client.connection.count"
+	dbConnectionMaxMetric        = "db.client.connection.max"
+	dbConnectionPendingMetric    = "db.client.connection.pending_requests"
+	dbConnectionCreateTimeMetric = "db.client.connection.create_time"
+	dbConnectionWaitTimeMetric   = "db.client.connection.wait_time"
+)
+
+// instrument is a name, description and constant attributes of metric instrument.
+type instrument struct {
+	name        string
+	description MetricDescription
+	attrs       []attribute.KeyValue
+	// semconv instruments are mapped to semantic conventions, their timers are reported in seconds
+	semconv bool
+}
+
+func connectionPoolMetric(name, pool, state string, description MetricDescription) instrument {
+	attrs := []attribute.KeyValue{attribute.String(dbConnectionPoolNameAttribute, pool)}
+	if state != "" {
+		attrs = append(attrs, attribute.String(dbConnectionStateAttribute, state))
+	}
+
+	return instrument{
+		name:        name,
+		description: description,
+		attrs:       attrs,
+	}
+}
+
+var (
+	connectionCountDescription = MetricDescription{
+		"Number of sessions that are currently in state described by the state attribute", "{connection}",
+	}
+	connectionMaxDescription      = MetricDescription{"Maximum number of sessions allowed", "{connection}"}
+	connectionPendingDescription  = MetricDescription{"Number of pending requests for a session", "{request}"}
+	connectionWaitTimeDescription = MetricDescription{"The time it took to obtain a session from the pool", "s"}
+)
+
+// semconvMetrics maps ydb-go-sdk sessions pools metrics by dotted path to semantic conventions.
+// YDB sessions are reported as connections of database client.
+var semconvMetrics = map[string]instrument{
+	"ydb.query.pool.size.idle": connectionPoolMetric(dbConnectionCountMetric, "query", "idle",
+		connectionCountDescription),
+	"ydb.query.pool.size.in_use": connectionPoolMetric(dbConnectionCountMetric, "query", "used",
+		connectionCountDescription),
+	"ydb.query.pool.size.limit": connectionPoolMetric(dbConnectionMaxMetric, "query", "",
+		connectionMaxDescription),
+	"ydb.query.pool.size.waiters_queue": connectionPoolMetric(dbConnectionPendingMetric, "query", "",
+		connectionPendingDescription),
+	"ydb.query.session.create.latency": connectionPoolMetric(dbConnectionCreateTimeMetric, "query", "",
+		MetricDescription{"The time it took to create a new session", "s"}),
+	"ydb.query.pool.get.latency": connectionPoolMetric(dbConnectionWaitTimeMetric, "query", "",
+		connectionWaitTimeDescription),
+
+	// operation and collection of query latency are labels derived from query text
+	"ydb.query.operation.latency": {
+		name:        dbOperationDurationMetric,
+		description: MetricDescription{"Duration of database client operations", "s"},
+	},
+
+	"ydb.table.pool.idle": connectionPoolMetric(dbConnectionCountMetric, "table", "idle",
+		connectionCountDescription),
+	"ydb.table.pool.in_use": connectionPoolMetric(dbConnectionCountMetric, "table", "used",
+		connectionCountDescription),
+	"ydb.table.pool.limit": connectionPoolMetric(dbConnectionMaxMetric, "table", "",
+		connectionMaxDescription),
+	"ydb.table.pool.wait": connectionPoolMetric(dbConnectionPendingMetric, "table", "",
+		connectionPendingDescription),
+	"ydb.table.pool.get.latency": connectionPoolMetric(dbConnectionWaitTimeMetric, "table", "",
+		connectionWaitTimeDescription),
+}
+
+// semconvOperations maps latency timers of single query executions by query service client,
+// sessions and transactions to db.operation.name of db.client.operation.duration. Executions do not
+// nest, so each of them is counted once. Latencies of retry loops, pools, sessions and database/sql
+// wrap these executions and keep ydb-go-sdk names.
+var semconvOperations = map[string]string{
+	"ydb.query.do.exec.latency":       "query.exec",
+	"ydb.query.query.latency":         "query.query",
+	"ydb.query.row.latency":           "query.row",
+	"ydb.query.result.set.latency":    "query.result.set",
+	"ydb.query.session.exec.latency":  "query.session.exec",
+	"ydb.query.session.query.latency": "query.session.query",
+	"ydb.query.tx.exec.latency":       "query.tx.exec",
+	"ydb.query.tx.query.latency":      "query.tx.query",
+	"ydb.query.tx.row.latency":        "query.tx.row",
+	"ydb.query.tx.result.set.latency": "query.tx.result.set",
+}
+
+// semconvInstrument returns instrument of semantic conventions for ydb-go-sdk metric.
+// Latency timers of semconvOperations are mapped to db.client.operation.duration unless query
+// operations are enabled, which report the same executions with db.operation.name of YQL statements.
+func semconvInstrument(system, name string, queryOperations bool) (instrument, bool) {
+	path := joinMetricPath(system, name)
+	systemAttr := attribute.String(dbSystemNameAttribute, dbSystemName)
+
+	if i, ok := semconvMetrics[path]; ok {
+		i.attrs = append([]attribute.KeyValue{systemAttr}, i.attrs...)
+		i.semconv = true
+
+		return i, true
+	}
+
+	if operation, ok := semconvOperations[path]; ok && !queryOperations {
+		return instrument{
+			name:        dbOperationDurationMetric,
+			description: MetricDescription{"Duration of database client operations", "s"},
+			attrs: []attribute.KeyValue{
+				systemAttr,
+				attribute.String(dbOperationNameAttribute, operation),
+			},
+			semconv: true,
+		}, true
+	}
+
+	return instrument{}, false
+}
@@ -0,0 +1,103 @@
+package ydb
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxCachedSeries is a limit of cached measurement options per instrument.
+const maxCachedSeries = 1024
+
+// seriesKey identifies series by labels and driver identity.
+type seriesKey struct {
+	labels   string
+	identity *[]attribute.KeyValue
+}
+
+// seriesOptions are precomputed measurement options of series, so measurements do not build
+// attribute sets and do not allocate.
+type seriesOptions struct {
+	add    []metric.AddOption
+	record []metric.RecordOption
+}
+
+func newSeriesOptions(attrs []attribute.KeyValue) *seriesOptions {
+	option := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+	return &seriesOptions{
+		add:    []metric.AddOption{option},
+		record: []metric.RecordOption{option},
+	}
+}
+
+// seriesCache caches options of at most maxCachedSeries series, options of other series are
+// computed on each call.
+type seriesCache struct {
+	mu      sync.RWMutex
+	options map[seriesKey]*seriesOptions
+}
+
+// get returns cached options of series or options of attributes built by attrs.
+func (c *seriesCache) get(key seriesKey, attrs func() []attribute.KeyValue) *seriesOptions {
+	c.mu.RLock()
+	options, ok := c.options[key]
+	c.mu.RUnlock()
+
+	if ok {
+		return options
+	}
+
+	options = newSeriesOptions(attrs())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.options[key]; ok {
+		return cached
+	}
+
+	if c.options == nil {
+		c.options = make(map[seriesKey]*seriesOptions)
+	}
+
+	if len(c.options) < maxCachedSeries {
+		c.options[key] = options
+	}
+
+	return options
+}
+
+// seriesOptionsFor returns options of series with labels, constant attributes of instrument and
+// driver identity. Series over cardinality limit are folded into overflow series.
+func seriesOptionsFor(
+	cache *seriesCache,
+	limiter *cardinalityLimiter,
+	labels map[string]string,
+	labelNames []string,
+	constAttrs []attribute.KeyValue,
+	identity *driverIdentity,
+) *seriesOptions {
+	snapshot := identity.snapshot()
+	key := seriesKey{
+		labels:   labelsCacheKey(labels, labelNames),
+		identity: snapshot,
+	}
+	if limiter != nil && !limiter.allow(key.labels) {
+		key.labels = overflowLabelsCacheKey
+
+		return cache.get(key, func() []attribute.KeyValue {
+			return overflowAttributes(constAttrs, snapshot)
+		})
+	}
+
+	return cache.get(key, func() []attribute.KeyValue {
+		attrs := append(labelsToAttributes(labels, labelNames), constAttrs...)
+		if snapshot != nil {
+			attrs = append(attrs, *snapshot...)
+		}
+
+		return attrs
+	})
+}
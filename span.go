@@ -1,7 +1,11 @@
 package ydb
 
 import (
+	"context"
+	"sync/atomic"
+
 	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	otelTrace "go.opentelemetry.io/otel/trace"
 )
@@ -9,7 +13,53 @@ import (
 var _ spans.Span = (*span)(nil)
 
 type span struct {
-	span otelTrace.Span
+	span    otelTrace.Span
+	adapter *adapter
+	// state is nil for spans which were not started by adapter
+	state *spanState
+	// started is true for span returned by Start, false for spans from context
+	started bool
+}
+
+type spanStateCtxKey struct{}
+
+// spanState is shared by all wrappers of span started by adapter.
+type spanState struct {
+	owner  otelTrace.Span
+	budget *eventsBudget
+
+	// attrs are per-call attributes from context of span start which are added to span events
+	attrs []attribute.KeyValue
+
+	// idempotent is an idempotency of operation from ydb-go-sdk span fields or of parent operation
+	idempotent bool
+	// retryLoop is true for spans of ydb-go-sdk retry loops which have own idempotent field
+	retryLoop bool
+	// gaveUp is set when retry loop ends with error
+	gaveUp atomic.Bool
+
+	failed atomic.Bool
+}
+
+func contextWithSpanState(ctx context.Context, state *spanState) context.Context {
+	return context.WithValue(ctx, spanStateCtxKey{}, state)
+}
+
+// parentSpanState returns state of the nearest span started by adapter in ctx.
+func parentSpanState(ctx context.Context) *spanState {
+	state, _ := ctx.Value(spanStateCtxKey{}).(*spanState)
+
+	return state
+}
+
+// spanStateFromContext returns state of span s if it was started by adapter.
+func spanStateFromContext(ctx context.Context, s otelTrace.Span) *spanState {
+	state, ok := ctx.Value(spanStateCtxKey{}).(*spanState)
+	if !ok || state.owner != s {
+		return nil
+	}
+
+	return state
 }
 
 func (s *span) ID() (_ string, valid bool) {
@@ -17,18 +67,67 @@ func (s *span) ID() (_ string, valid bool) {
 }
 
 func (s *span) Log(msg string, fields ...spans.KeyValue) {
-	s.span.AddEvent(msg, otelTrace.WithAttributes(fieldsToAttributes(fields)...))
+	if s.state != nil && s.state.budget != nil && !s.state.budget.allow(msg) {
+		return
+	}
+
+	attrs := s.adapter.converters.fieldsToAttributes(fields)
+	attrs = append(attrs, s.contextAttributes()...)
+
+	s.span.AddEvent(msg, otelTrace.WithAttributes(attrs...))
 }
 
 func (s *span) Warn(err error, fields ...spans.KeyValue) {
-	s.span.RecordError(err, otelTrace.WithAttributes(fieldsToAttributes(fields)...))
+	s.recordError(err, fields)
 }
 
 func (s *span) Error(err error, fields ...spans.KeyValue) {
-	s.span.RecordError(err, otelTrace.WithAttributes(fieldsToAttributes(fields)...))
+	s.recordError(err, fields)
+
+	if s.state != nil {
+		s.state.failed.Store(true)
+		switch {
+		case !s.adapter.retryDecisions:
+		case s.state.retryLoop:
+			// errors of attempts are logged into retry loop span from context, but the loop gives up
+			// only when its own span ends with error
+			if s.started {
+				s.state.gaveUp.Store(true)
+			}
+		default:
+			s.span.SetAttributes(attribute.String(retryDecisionAttribute,
+				retryDecisionFor(err, s.state.idempotent),
+			))
+		}
+	}
+
+	if kind := classifyContextError(err); kind != notContextError {
+		s.span.SetAttributes(kind.attributes()...)
+		s.span.SetStatus(s.adapter.contextErrorStatus, err.Error())
+
+		return
+	}
+
 	s.span.SetStatus(codes.Error, err.Error())
 }
 
+func (s *span) recordError(err error, fields []spans.KeyValue) {
+	attrs := s.adapter.converters.fieldsToAttributes(fields)
+	attrs = append(attrs, issuesToAttributes(err)...)
+	attrs = append(attrs, s.contextAttributes()...)
+
+	s.span.RecordError(err, otelTrace.WithAttributes(attrs...))
+}
+
+// contextAttributes returns per-call attributes of span.
+func (s *span) contextAttributes() []attribute.KeyValue {
+	if s.state == nil {
+		return nil
+	}
+
+	return s.state.attrs
+}
+
 func (s *span) TraceID() (string, bool) {
 	traceID := s.span.SpanContext().TraceID()
 
@@ -37,12 +136,38 @@ func (s *span) TraceID() (string, bool) {
 
 func (s *span) Link(link spans.Span, fields ...spans.KeyValue) {
 	s.span.AddLink(otelTrace.Link{
-		SpanContext: link.(*span).span.SpanContext(), //nolint:forcetypeassert
-		Attributes:  fieldsToAttributes(fields),
+		SpanContext: spanContextOf(link, s.adapter),
+		Attributes:  s.adapter.converters.fieldsToAttributes(fields),
 	})
 }
 
 func (s *span) End(fields ...spans.KeyValue) {
-	s.span.SetAttributes(fieldsToAttributes(fields)...)
+	s.span.SetAttributes(s.adapter.converters.fieldsToAttributes(fields)...)
+	if s.state != nil {
+		if s.state.budget != nil {
+			s.span.SetAttributes(s.state.budget.attributes()...)
+		}
+		if s.adapter.retryDecisions && s.state.gaveUp.Load() {
+			s.span.SetAttributes(attribute.String(retryDecisionAttribute, retryDecisionGiveUp))
+		}
+		if s.adapter.okStatus && !s.state.failed.Load() {
+			s.span.SetStatus(codes.Ok, "")
+		}
+		if s.adapter.leaks != nil {
+			s.adapter.leaks.untrack(s.state)
+		}
+	}
 	s.span.End()
 }
+
+// spanContextOf returns span context of link. For fan-out spans the span of the same member is used.
+func spanContextOf(link spans.Span, member *adapter) otelTrace.SpanContext {
+	switch l := link.(type) {
+	case *span:
+		return l.span.SpanContext()
+	case *fanOutSpan:
+		return l.memberSpan(member).span.SpanContext()
+	default:
+		return otelTrace.SpanContext{}
+	}
+}
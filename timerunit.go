@@ -0,0 +1,57 @@
+package ydb
+
+import (
+	"time"
+)
+
+// TimerUnit is a unit of timer metrics.
+type TimerUnit string
+
+const (
+	// TimerUnitSeconds reports timers in seconds, the default unit.
+	TimerUnitSeconds TimerUnit = "s"
+	// TimerUnitMilliseconds reports timers in milliseconds.
+	TimerUnitMilliseconds TimerUnit = "ms"
+	// TimerUnitMicroseconds reports timers in microseconds.
+	TimerUnitMicroseconds TimerUnit = "us"
+)
+
+// duration returns duration of one unit, zero for unknown units.
+func (u TimerUnit) duration() time.Duration {
+	switch u {
+	case TimerUnitSeconds:
+		return time.Second
+	case TimerUnitMilliseconds:
+		return time.Millisecond
+	case TimerUnitMicroseconds:
+		return time.Microsecond
+	default:
+		return 0
+	}
+}
+
+// value returns d in units.
+func (u TimerUnit) value(d time.Duration) float64 {
+	if u == TimerUnitSeconds {
+		return d.Seconds()
+	}
+
+	return float64(d) / float64(u.duration())
+}
+
+// buckets returns buckets in seconds scaled to units.
+func (u TimerUnit) buckets(seconds []float64) []float64 {
+	return TimerUnitSeconds.rescale(seconds, u)
+}
+
+// rescale returns buckets in units converted to buckets in other units.
+func (u TimerUnit) rescale(buckets []float64, other TimerUnit) []float64 {
+	scale := float64(u.duration()) / float64(other.duration())
+
+	rescaled := make([]float64, len(buckets))
+	for i, b := range buckets {
+		rescaled[i] = b * scale
+	}
+
+	return rescaled
+}
@@ -0,0 +1,89 @@
+package ydb
+
+import (
+	"path"
+)
+
+// metricView changes ydb-go-sdk instruments with names matching pattern.
+type metricView struct {
+	pattern string
+	rename  string
+	drop    bool
+	// attributeKeys is an allow-list of attributes, nil keeps all attributes
+	attributeKeys []string
+}
+
+func (v metricView) matches(name string) bool {
+	matched, err := path.Match(v.pattern, name)
+
+	return err == nil && matched
+}
+
+// instrumentView is a result of all views matching instrument.
+type instrumentView struct {
+	name string
+	drop bool
+	// allowed is a set of allowed attributes, nil allows all attributes
+	allowed map[string]struct{}
+}
+
+// resolveMetricViews applies views matching instrument name in order of options. Views are matched
+// with original instrument name, the last matching rename and allow-list win.
+func resolveMetricViews(views []metricView, name string) instrumentView {
+	v := instrumentView{name: name}
+
+	for _, view := range views {
+		if !view.matches(name) {
+			continue
+		}
+
+		if view.rename != "" {
+			v.name = view.rename
+		}
+
+		if view.drop {
+			v.drop = true
+		}
+
+		if view.attributeKeys != nil {
+			v.allowed = make(map[string]struct{}, len(view.attributeKeys))
+			for _, key := range view.attributeKeys {
+				v.allowed[key] = struct{}{}
+			}
+		}
+	}
+
+	return v
+}
+
+// labelNames returns allowed label names.
+func (v instrumentView) labelNames(labelNames []string) []string {
+	if v.allowed == nil {
+		return labelNames
+	}
+
+	allowed := make([]string, 0, len(labelNames))
+	for _, name := range labelNames {
+		if _, ok := v.allowed[name]; ok {
+			allowed = append(allowed, name)
+		}
+	}
+
+	return allowed
+}
+
+// filterLabels returns labels with allowed names only, nil allowed keeps all labels.
+func filterLabels(labels map[string]string, allowed map[string]struct{}) map[string]string {
+	if allowed == nil || len(labels) == 0 {
+		return labels
+	}
+
+	filtered := make(map[string]string, len(allowed))
+	for name, value := range labels {
+		if _, ok := allowed[name]; ok {
+			filtered[name] = value
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,374 @@
+package ydb
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/spans"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	dbOperationNameAttribute    = "db.operation.name"
+	dbCollectionNameAttribute   = "db.collection.name"
+	ydbCollectionNamesAttribute = "ydb.collection.names"
+	ydbOperationAttribute       = "ydb.operation"
+)
+
+// queryFieldKeys are keys of span fields which ydb-go-sdk uses for query text.
+var queryFieldKeys = []string{"query", "Query"}
+
+type yqlTokenKind int
+
+const (
+	yqlWord yqlTokenKind = iota
+	yqlQuotedIdentifier
+	yqlPunct
+)
+
+type yqlToken struct {
+	kind yqlTokenKind
+	text string
+}
+
+func (t yqlToken) is(keyword string) bool {
+	return t.kind == yqlWord && strings.EqualFold(t.text, keyword)
+}
+
+// yqlSummary is a result of lightweight YQL analysis.
+type yqlSummary struct {
+	// operations are distinct statement types in order of appearance, e.g. SELECT or CREATE TABLE
+	operations []string
+	// collections are distinct table paths in order of appearance
+	collections []string
+}
+
+// operationName returns db.operation.name for query. Multi-statement queries
+// with different statement types are reported as types joined with ';'.
+func (s yqlSummary) operationName() string {
+	return strings.Join(s.operations, ";")
+}
+
+// collectionName returns db.collection.name if query touches exactly one table.
+func (s yqlSummary) collectionName() string {
+	if len(s.collections) != 1 {
+		return ""
+	}
+
+	return s.collections[0]
+}
+
+// spanName returns span name like "UPSERT series" or empty string if query has no data statements.
+func (s yqlSummary) spanName() string {
+	operation := s.operationName()
+	if operation == "" {
+		return ""
+	}
+
+	if collection := s.collectionName(); collection != "" {
+		return operation + " " + path.Base(collection)
+	}
+
+	return operation
+}
+
+// attributes returns db.operation.name and db.collection.name span attributes.
+func (s yqlSummary) attributes() []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 3)
+	if operation := s.operationName(); operation != "" {
+		attrs = append(attrs, attribute.String(dbOperationNameAttribute, operation))
+	}
+
+	if collection := s.collectionName(); collection != "" {
+		attrs = append(attrs, attribute.String(dbCollectionNameAttribute, collection))
+	}
+
+	if len(s.collections) > 0 {
+		attrs = append(attrs, attribute.StringSlice(ydbCollectionNamesAttribute, s.collections))
+	}
+
+	return attrs
+}
+
+// queryFromFields returns query text from span fields.
+func queryFromFields(fields []spans.KeyValue) (string, bool) {
+	for _, field := range fields {
+		if field.Type() == spans.StringType && slices.Contains(queryFieldKeys, field.Key()) {
+			return field.StringValue(), true
+		}
+	}
+
+	return "", false
+}
+
+// sanitizeQueryFields returns copy of fields with literals of query text replaced by placeholders.
+func sanitizeQueryFields(fields []spans.KeyValue) []spans.KeyValue {
+	sanitized := make([]spans.KeyValue, 0, len(fields))
+	for _, field := range fields {
+		if field.Type() == spans.StringType && slices.Contains(queryFieldKeys, field.Key()) {
+			field = log.String(field.Key(), sanitizeYQL(field.StringValue()))
+		}
+		sanitized = append(sanitized, field)
+	}
+
+	return sanitized
+}
+
+// analyzeYQL derives statement types and table paths from YQL query text.
+// DECLARE, PRAGMA and USE statements are skipped, named expressions contribute tables only.
+func analyzeYQL(query string) yqlSummary {
+	var summary yqlSummary
+
+	for _, statement := range splitYQLStatements(tokenizeYQL(query)) {
+		operation, collections := analyzeYQLStatement(statement)
+		if operation != "" && !slices.Contains(summary.operations, operation) {
+			summary.operations = append(summary.operations, operation)
+		}
+
+		for _, collection := range collections {
+			if !slices.Contains(summary.collections, collection) {
+				summary.collections = append(summary.collections, collection)
+			}
+		}
+	}
+
+	return summary
+}
+
+func analyzeYQLStatement(tokens []yqlToken) (operation string, collections []string) {
+	if len(tokens) == 0 {
+		return "", nil
+	}
+
+	first := tokens[0]
+	switch {
+	case first.is("DECLARE"), first.is("PRAGMA"), first.is("USE"):
+		return "", nil
+	case first.kind == yqlWord && strings.HasPrefix(first.text, "$"):
+		return "", sourceTables(tokens)
+	case first.is("UPSERT"), first.is("REPLACE"), first.is("INSERT"):
+		return strings.ToUpper(first.text), appendTable(nil, tokenAfter(tokens, "INTO"), sourceTables(tokens)...)
+	case first.is("UPDATE"):
+		return "UPDATE", appendTable(nil, tokenAt(tokens, 1), sourceTables(tokens)...)
+	case first.is("DELETE"):
+		return "DELETE", appendTable(nil, tokenAfter(tokens, "FROM"), sourceTables(tokens)...)
+	case first.is("CREATE"), first.is("ALTER"), first.is("DROP"):
+		return schemeStatement(tokens)
+	case first.kind == yqlWord:
+		return strings.ToUpper(first.text), sourceTables(tokens)
+	default:
+		return "", sourceTables(tokens)
+	}
+}
+
+// schemeStatement handles statements like "CREATE TABLE IF NOT EXISTS `a/b`".
+func schemeStatement(tokens []yqlToken) (operation string, collections []string) {
+	i := 1
+	for i < len(tokens) && (tokens[i].is("TEMP") || tokens[i].is("TEMPORARY") || tokens[i].is("EXTERNAL")) {
+		i++
+	}
+
+	object := tokenAt(tokens, i)
+	if object.kind != yqlWord {
+		return strings.ToUpper(tokens[0].text), nil
+	}
+
+	operation = strings.ToUpper(tokens[0].text + " " + object.text)
+
+	i++
+	for i < len(tokens) && (tokens[i].is("IF") || tokens[i].is("NOT") || tokens[i].is("EXISTS")) {
+		i++
+	}
+
+	return operation, appendTable(nil, tokenAt(tokens, i))
+}
+
+// sourceTables returns tables referenced by FROM and JOIN clauses.
+func sourceTables(tokens []yqlToken) (tables []string) {
+	for i, token := range tokens {
+		if !token.is("FROM") && !token.is("JOIN") {
+			continue
+		}
+
+		if next := tokenAt(tokens, i+2); next.kind == yqlPunct && next.text == "(" {
+			// table function like AS_TABLE($values)
+			continue
+		}
+
+		tables = appendTable(tables, tokenAt(tokens, i+1))
+	}
+
+	return tables
+}
+
+// appendTable appends table path from token if it looks like table reference and then more tables.
+func appendTable(tables []string, token yqlToken, more ...string) []string {
+	switch {
+	case token.kind == yqlQuotedIdentifier && token.text != "":
+		tables = append(tables, token.text)
+	case token.kind == yqlWord && !strings.HasPrefix(token.text, "$"):
+		tables = append(tables, token.text)
+	}
+
+	return append(tables, more...)
+}
+
+func tokenAt(tokens []yqlToken, i int) yqlToken {
+	if i < 0 || i >= len(tokens) {
+		return yqlToken{kind: yqlPunct}
+	}
+
+	return tokens[i]
+}
+
+func tokenAfter(tokens []yqlToken, keyword string) yqlToken {
+	for i, token := range tokens {
+		if token.is(keyword) {
+			return tokenAt(tokens, i+1)
+		}
+	}
+
+	return yqlToken{kind: yqlPunct}
+}
+
+func splitYQLStatements(tokens []yqlToken) (statements [][]yqlToken) {
+	start := 0
+	for i, token := range tokens {
+		if token.kind == yqlPunct && token.text == ";" {
+			if i > start {
+				statements = append(statements, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+
+	if start < len(tokens) {
+		statements = append(statements, tokens[start:])
+	}
+
+	return statements
+}
+
+// tokenizeYQL splits query into words, quoted identifiers and punctuation
+// skipping whitespace, comments and string literals.
+//
+//nolint:funlen
+func tokenizeYQL(query string) (tokens []yqlToken) {
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(query[i:], "--"):
+			i = skipUntil(query, i+2, "\n")
+		case strings.HasPrefix(query[i:], "/*"):
+			i = skipUntil(query, i+2, "*/")
+		case strings.HasPrefix(query[i:], "@@"):
+			i = skipUntil(query, i+2, "@@")
+		case c == '\'' || c == '"':
+			i = skipString(query, i+1, c)
+		case c == '`':
+			end := strings.IndexByte(query[i+1:], '`')
+			if end < 0 {
+				return tokens
+			}
+			tokens = append(tokens, yqlToken{kind: yqlQuotedIdentifier, text: query[i+1 : i+1+end]})
+			i += end + 2
+		case isYQLWordChar(rune(c)) || c == '$':
+			j := i + 1
+			for j < len(query) && isYQLWordChar(rune(query[j])) {
+				j++
+			}
+			tokens = append(tokens, yqlToken{kind: yqlWord, text: query[i:j]})
+			i = j
+		default:
+			tokens = append(tokens, yqlToken{kind: yqlPunct, text: query[i : i+1]})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+func isYQLWordChar(r rune) bool {
+	return r == '_' || r == '.' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// skipUntil returns position after terminator or end of query.
+func skipUntil(query string, from int, terminator string) int {
+	end := strings.Index(query[from:], terminator)
+	if end < 0 {
+		return len(query)
+	}
+
+	return from + end + len(terminator)
+}
+
+// skipString returns position after closing quote of string literal.
+func skipString(query string, from int, quote byte) int {
+	for i := from; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+
+	return len(query)
+}
+
+// sanitizeYQL replaces string and numeric literals and comment bodies of query with '?' placeholders
+// keeping identifiers and parameters like $id.
+func sanitizeYQL(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case strings.HasPrefix(query[i:], "--"):
+			i = skipUntil(query, i+2, "\n")
+			b.WriteString("-- ?")
+			if strings.HasSuffix(query[:i], "\n") {
+				b.WriteByte('\n')
+			}
+		case strings.HasPrefix(query[i:], "/*"):
+			i = skipUntil(query, i+2, "*/")
+			b.WriteString("/* ? */")
+		case strings.HasPrefix(query[i:], "@@"):
+			b.WriteByte('?')
+			i = skipUntil(query, i+2, "@@")
+		case c == '\'' || c == '"':
+			b.WriteByte('?')
+			i = skipString(query, i+1, c)
+			for i < len(query) && unicode.IsLetter(rune(query[i])) {
+				// literal type suffix like 'abc'u
+				i++
+			}
+		case c == '`':
+			end := skipUntil(query, i+1, "`")
+			b.WriteString(query[i:end])
+			i = end
+		case isYQLWordChar(rune(c)) || c == '$':
+			j := i + 1
+			for j < len(query) && isYQLWordChar(rune(query[j])) {
+				j++
+			}
+			if unicode.IsDigit(rune(c)) {
+				b.WriteByte('?')
+			} else {
+				b.WriteString(query[i:j])
+			}
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String()
+}
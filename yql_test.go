@@ -0,0 +1,115 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+)
+
+func TestAnalyzeYQL(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		query       string
+		operation   string
+		collections []string
+		spanName    string
+	}{
+		{
+			name:        "select",
+			query:       "SELECT series_id, title FROM series LIMIT 1000;",
+			operation:   "SELECT",
+			collections: []string{"series"},
+			spanName:    "SELECT series",
+		},
+		{
+			name: "declare and replace from table function",
+			query: "DECLARE $values AS List<Struct<id: Uint64>>;\n" +
+				"REPLACE INTO `/local/native/query/series`\nSELECT id FROM AS_TABLE($values);",
+			operation:   "REPLACE",
+			collections: []string{"/local/native/query/series"},
+			spanName:    "REPLACE series",
+		},
+		{
+			name:        "create table if not exists",
+			query:       "PRAGMA TablePathPrefix(\"/local\");\nCREATE TABLE IF NOT EXISTS `a/b/series` (id Uint64, PRIMARY KEY (id))",
+			operation:   "CREATE TABLE",
+			collections: []string{"a/b/series"},
+			spanName:    "CREATE TABLE series",
+		},
+		{
+			name: "multi statement with comments and strings",
+			query: "-- FROM comment\n/* UPSERT INTO nothing */\n" +
+				"UPSERT INTO seasons SELECT * FROM series WHERE title = 'FROM x; DROP';\n" +
+				"SELECT * FROM episodes AS e JOIN `seasons` AS s ON e.season_id = s.season_id;",
+			operation:   "UPSERT;SELECT",
+			collections: []string{"seasons", "series", "episodes"},
+			spanName:    "UPSERT;SELECT",
+		},
+		{
+			name:        "named expression",
+			query:       "$x = (SELECT * FROM series); SELECT * FROM $x;",
+			operation:   "SELECT",
+			collections: []string{"series"},
+			spanName:    "SELECT series",
+		},
+		{
+			name:  "declare only",
+			query: "DECLARE $id AS Uint64;",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := analyzeYQL(tt.query)
+			require.Equal(t, tt.operation, summary.operationName())
+			require.Equal(t, tt.collections, summary.collections)
+			require.Equal(t, tt.spanName, summary.spanName())
+		})
+	}
+}
+
+func TestSanitizeYQL(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		query string
+		exp   string
+	}{
+		{
+			name:  "strings and numbers",
+			query: "SELECT * FROM `series_2024` WHERE title = 'it\\'s' AND id IN (1, 2.5e3) LIMIT 10;",
+			exp:   "SELECT * FROM `series_2024` WHERE title = ? AND id IN (?, ?) LIMIT ?;",
+		},
+		{
+			name:  "typed literals and multiline strings",
+			query: "UPSERT INTO t (a, b, c) VALUES (\"x\"u, @@multi\nline@@, 7ul);",
+			exp:   "UPSERT INTO t (a, b, c) VALUES (?, ?, ?);",
+		},
+		{
+			name:  "parameters and comments",
+			query: "-- user 'admin'\nDECLARE $id1 AS Uint64; SELECT * FROM t WHERE id = $id1 /* id 42 */ -- end",
+			exp:   "-- ?\nDECLARE $id1 AS Uint64; SELECT * FROM t WHERE id = $id1 /* ? */ -- ?",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.exp, sanitizeYQL(tt.query))
+		})
+	}
+}
+
+func TestAdapterQuerySpanNames(t *testing.T) {
+	a, recorder := newTestSpansAdapter(WithQuerySpanNames())
+
+	_, s := a.Start(context.Background(), "ydb.query.Client.Exec",
+		log.String("Query", "UPSERT INTO series SELECT * FROM AS_TABLE($rows)"),
+	)
+	s.End()
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "UPSERT series", ended[0].Name())
+
+	attrs := spanAttributes(ended[0])
+	require.Equal(t, "UPSERT", attrs[dbOperationNameAttribute].AsString())
+	require.Equal(t, "series", attrs[dbCollectionNameAttribute].AsString())
+	require.Equal(t, "ydb.query.Client.Exec", attrs[ydbOperationAttribute].AsString())
+}